@@ -1,10 +1,13 @@
 package client
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 
+	"github.com/pegondo/starwars-service/internal/errors"
 	"github.com/pegondo/starwars-service/internal/handler"
 	"github.com/pegondo/starwars-service/internal/resources/swapi"
 )
@@ -29,6 +32,15 @@ type Response[T swapi.Resource] struct {
 	Response handler.Response[T]
 }
 
+// ResourceResponse is the response returned by the client for the endpoints
+// that return a single resource.
+type ResourceResponse[T swapi.Resource] struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Resource is the resource in the response.
+	Resource T
+}
+
 // requestOpts represents the options of the request.
 type requestOpts struct {
 	// pageNumber is the number of the pagination page requested.
@@ -43,6 +55,9 @@ type requestOpts struct {
 	sortField string
 	// sortOrder is the order to sort by.
 	sortOrder string
+	// expand is the comma separated list of link fields to expand. If "", no
+	// field should be expanded.
+	expand string
 }
 
 // NewRequestOpts creates and returns a request options structure with the given
@@ -63,6 +78,13 @@ func NewRequestOpts(
 	}
 }
 
+// WithExpand returns a copy of opts that requests to expand the given comma
+// separated list of link fields.
+func (opts requestOpts) WithExpand(expand string) requestOpts {
+	opts.expand = expand
+	return opts
+}
+
 // buildUrl builds a URL to request the addr in c with the given endpoint and
 // options.
 func (c *Client) buildUrl(endpoint string, opts requestOpts) string {
@@ -84,6 +106,9 @@ func (c *Client) buildUrl(endpoint string, opts requestOpts) string {
 	if opts.sortOrder != "" {
 		query.Add("sortOrder", opts.sortOrder)
 	}
+	if opts.expand != "" {
+		query.Add("expand", opts.expand)
+	}
 	reqUrl.RawQuery = query.Encode()
 
 	return reqUrl.String()
@@ -93,3 +118,87 @@ func (c *Client) buildUrl(endpoint string, opts requestOpts) string {
 func isStatusOk(statusCode int) bool {
 	return http.StatusOK <= statusCode && statusCode < http.StatusMultipleChoices
 }
+
+// retrieve calls the given collection endpoint from c.addr and returns its
+// response. If the response is valid, its data will be in resourcesResp. If the
+// response is invalid and the response has errors.ResponseError format, err
+// will contain that error; otherwise, err will contain the returned error.
+func retrieve[T swapi.Resource](c *Client, endpoint string, opts requestOpts) (resourcesResp Response[T], err error) {
+	url := c.buildUrl(endpoint, opts)
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return resourcesResp, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resourcesResp, err
+	}
+
+	if isStatusOk(resp.StatusCode) {
+		// If it's a success response, return it.
+		var apiResp handler.Response[T]
+		if err = json.Unmarshal(bodyBytes, &apiResp); err == nil {
+			return Response[T]{
+				StatusCode: resp.StatusCode,
+				Response:   apiResp,
+			}, nil
+		}
+	} else {
+		// If the response is an error response, return it as an error.
+		var errResp errors.ResponseError
+		if err = json.Unmarshal(bodyBytes, &errResp); err == nil {
+			return resourcesResp, &errResp
+		}
+	}
+
+	return resourcesResp, err
+}
+
+// retrieveById calls the given endpoint from c.addr to retrieve the resource
+// with the given id and options and returns its response. If the response is invalid and
+// the response has errors.ResponseError format, err will contain that error;
+// otherwise, err will contain the returned error.
+func retrieveById[T swapi.Resource](c *Client, endpoint, id string, opts requestOpts) (resourceResp ResourceResponse[T], err error) {
+	reqUrl := c.buildUrl(fmt.Sprintf("%s/%s", endpoint, url.PathEscape(id)), opts)
+
+	resp, err := http.Get(reqUrl)
+	if err != nil {
+		return resourceResp, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resourceResp, err
+	}
+
+	if isStatusOk(resp.StatusCode) {
+		// If it's a success response, return it.
+		var resource T
+		if err = json.Unmarshal(bodyBytes, &resource); err == nil {
+			return ResourceResponse[T]{
+				StatusCode: resp.StatusCode,
+				Resource:   resource,
+			}, nil
+		}
+	} else {
+		// If the response is an error response, return it as an error.
+		var errResp errors.ResponseError
+		if err = json.Unmarshal(bodyBytes, &errResp); err == nil {
+			return resourceResp, &errResp
+		}
+	}
+
+	return resourceResp, err
+}
+
+// retrieveLinked calls the endpoint from c.addr that returns the resources
+// linked in the given field of the resource with the given id in the given
+// collection, and returns its response.
+func retrieveLinked[T swapi.Resource](c *Client, collection, id, field string, opts requestOpts) (resourcesResp Response[T], err error) {
+	endpoint := fmt.Sprintf("%s/%s/%s", collection, url.PathEscape(id), field)
+	return retrieve[T](c, endpoint, opts)
+}
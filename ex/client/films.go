@@ -0,0 +1,61 @@
+package client
+
+import (
+	"github.com/pegondo/starwars-service/internal/resources/swapi"
+)
+
+// RetrieveFilms calls the retrieve films endpoint from c.addr and returns
+// its response. If the response is valid, its data will be in filmsResp. If
+// the response is invalid and the response has errors.ResponseError format, err
+// will contain that error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveFilms(opts requestOpts) (filmsResp Response[swapi.Film], err error) {
+	return retrieve[swapi.Film](c, "films", opts)
+}
+
+// RetrieveFilmById calls the retrieve film by id endpoint from c.addr with the
+// given options and returns its response. If the response is invalid and the
+// response has errors.ResponseError format, err will contain that error;
+// otherwise, err will contain the returned error.
+func (c *Client) RetrieveFilmById(id string, opts requestOpts) (filmResp ResourceResponse[swapi.Film], err error) {
+	return retrieveById[swapi.Film](c, "films", id, opts)
+}
+
+// RetrieveFilmCharacters calls the endpoint from c.addr that returns the
+// characters of the film with the given id, and returns its response. If the
+// response is invalid and the response has errors.ResponseError format, err
+// will contain that error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveFilmCharacters(id string, opts requestOpts) (charactersResp Response[swapi.Person], err error) {
+	return retrieveLinked[swapi.Person](c, "films", id, "characters", opts)
+}
+
+// RetrieveFilmPlanets calls the endpoint from c.addr that returns the planets
+// of the film with the given id, and returns its response. If the response is
+// invalid and the response has errors.ResponseError format, err will contain
+// that error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveFilmPlanets(id string, opts requestOpts) (planetsResp Response[swapi.Planet], err error) {
+	return retrieveLinked[swapi.Planet](c, "films", id, "planets", opts)
+}
+
+// RetrieveFilmStarships calls the endpoint from c.addr that returns the
+// starships of the film with the given id, and returns its response. If the
+// response is invalid and the response has errors.ResponseError format, err
+// will contain that error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveFilmStarships(id string, opts requestOpts) (starshipsResp Response[swapi.Starship], err error) {
+	return retrieveLinked[swapi.Starship](c, "films", id, "starships", opts)
+}
+
+// RetrieveFilmVehicles calls the endpoint from c.addr that returns the vehicles
+// of the film with the given id, and returns its response. If the response is
+// invalid and the response has errors.ResponseError format, err will contain
+// that error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveFilmVehicles(id string, opts requestOpts) (vehiclesResp Response[swapi.Vehicle], err error) {
+	return retrieveLinked[swapi.Vehicle](c, "films", id, "vehicles", opts)
+}
+
+// RetrieveFilmSpecies calls the endpoint from c.addr that returns the species
+// of the film with the given id, and returns its response. If the response is
+// invalid and the response has errors.ResponseError format, err will contain
+// that error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveFilmSpecies(id string, opts requestOpts) (speciesResp Response[swapi.Species], err error) {
+	return retrieveLinked[swapi.Species](c, "films", id, "species", opts)
+}
@@ -1,12 +1,6 @@
 package client
 
 import (
-	"encoding/json"
-	"io"
-	"net/http"
-
-	"github.com/pegondo/starwars-service/internal/errors"
-	"github.com/pegondo/starwars-service/internal/handler"
 	"github.com/pegondo/starwars-service/internal/resources/swapi"
 )
 
@@ -15,35 +9,45 @@ import (
 // response is invalid and the response has errors.ResponseError format, err
 // will contain that error; otherwise, err will contain the returned error.
 func (c *Client) RetrievePeople(opts requestOpts) (peopleResp Response[swapi.Person], err error) {
-	url := c.buildUrl("people", opts)
+	return retrieve[swapi.Person](c, "people", opts)
+}
 
-	resp, err := http.Get(url)
-	if err != nil {
-		return peopleResp, err
-	}
-	defer resp.Body.Close()
+// RetrievePersonById calls the retrieve person by id endpoint from c.addr with
+// the given options and returns its response. If the response is invalid and
+// the response has errors.ResponseError format, err will contain that error;
+// otherwise, err will contain the returned error.
+func (c *Client) RetrievePersonById(id string, opts requestOpts) (personResp ResourceResponse[swapi.Person], err error) {
+	return retrieveById[swapi.Person](c, "people", id, opts)
+}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return peopleResp, err
-	}
+// RetrievePersonFilms calls the endpoint from c.addr that returns the films of
+// the person with the given id, and returns its response. If the response is
+// invalid and the response has errors.ResponseError format, err will contain
+// that error; otherwise, err will contain the returned error.
+func (c *Client) RetrievePersonFilms(id string, opts requestOpts) (filmsResp Response[swapi.Film], err error) {
+	return retrieveLinked[swapi.Film](c, "people", id, "films", opts)
+}
 
-	if isStatusOk(resp.StatusCode) {
-		// If it's a success response, return it.
-		var apiResp handler.Response[swapi.Person]
-		if err = json.Unmarshal(bodyBytes, &apiResp); err == nil {
-			return Response[swapi.Person]{
-				StatusCode: resp.StatusCode,
-				Response:   apiResp,
-			}, nil
-		}
-	} else {
-		// If the response is an error response, return it as an error.
-		var errResp errors.ResponseError
-		if err = json.Unmarshal(bodyBytes, &errResp); err == nil {
-			return peopleResp, &errResp
-		}
-	}
+// RetrievePersonSpecies calls the endpoint from c.addr that returns the species
+// of the person with the given id, and returns its response. If the response is
+// invalid and the response has errors.ResponseError format, err will contain
+// that error; otherwise, err will contain the returned error.
+func (c *Client) RetrievePersonSpecies(id string, opts requestOpts) (speciesResp Response[swapi.Species], err error) {
+	return retrieveLinked[swapi.Species](c, "people", id, "species", opts)
+}
 
-	return peopleResp, err
+// RetrievePersonVehicles calls the endpoint from c.addr that returns the
+// vehicles of the person with the given id, and returns its response. If the
+// response is invalid and the response has errors.ResponseError format, err
+// will contain that error; otherwise, err will contain the returned error.
+func (c *Client) RetrievePersonVehicles(id string, opts requestOpts) (vehiclesResp Response[swapi.Vehicle], err error) {
+	return retrieveLinked[swapi.Vehicle](c, "people", id, "vehicles", opts)
+}
+
+// RetrievePersonStarships calls the endpoint from c.addr that returns the
+// starships of the person with the given id, and returns its response. If the
+// response is invalid and the response has errors.ResponseError format, err
+// will contain that error; otherwise, err will contain the returned error.
+func (c *Client) RetrievePersonStarships(id string, opts requestOpts) (starshipsResp Response[swapi.Starship], err error) {
+	return retrieveLinked[swapi.Starship](c, "people", id, "starships", opts)
 }
@@ -1,12 +1,6 @@
 package client
 
 import (
-	"encoding/json"
-	"io"
-	"net/http"
-
-	"github.com/pegondo/starwars-service/internal/errors"
-	"github.com/pegondo/starwars-service/internal/handler"
 	"github.com/pegondo/starwars-service/internal/resources/swapi"
 )
 
@@ -15,35 +9,29 @@ import (
 // response is invalid and the response has errors.ResponseError format, err
 // will contain that error; otherwise, err will contain the returned error.
 func (c *Client) RetrievePlanets(opts requestOpts) (planetsResp Response[swapi.Planet], err error) {
-	url := c.buildUrl("planets", opts)
-
-	resp, err := http.Get(url)
-	if err != nil {
-		return planetsResp, err
-	}
-	defer resp.Body.Close()
+	return retrieve[swapi.Planet](c, "planets", opts)
+}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return planetsResp, err
-	}
+// RetrievePlanetById calls the retrieve planet by id endpoint from c.addr with
+// the given options and returns its response. If the response is invalid and
+// the response has errors.ResponseError format, err will contain that error;
+// otherwise, err will contain the returned error.
+func (c *Client) RetrievePlanetById(id string, opts requestOpts) (planetResp ResourceResponse[swapi.Planet], err error) {
+	return retrieveById[swapi.Planet](c, "planets", id, opts)
+}
 
-	if isStatusOk(resp.StatusCode) {
-		// If it's a success response, return it.
-		var apiResp handler.Response[swapi.Planet]
-		if err = json.Unmarshal(bodyBytes, &apiResp); err == nil {
-			return Response[swapi.Planet]{
-				StatusCode: resp.StatusCode,
-				Response:   apiResp,
-			}, nil
-		}
-	} else {
-		// If the response is an error response, return it as an error.
-		var errResp *errors.ResponseError
-		if err = json.Unmarshal(bodyBytes, &errResp); err == nil {
-			return planetsResp, errResp
-		}
-	}
+// RetrievePlanetResidents calls the endpoint from c.addr that returns the
+// residents of the planet with the given id, and returns its response. If the
+// response is invalid and the response has errors.ResponseError format, err
+// will contain that error; otherwise, err will contain the returned error.
+func (c *Client) RetrievePlanetResidents(id string, opts requestOpts) (residentsResp Response[swapi.Person], err error) {
+	return retrieveLinked[swapi.Person](c, "planets", id, "residents", opts)
+}
 
-	return planetsResp, err
+// RetrievePlanetFilms calls the endpoint from c.addr that returns the films of
+// the planet with the given id, and returns its response. If the response is
+// invalid and the response has errors.ResponseError format, err will contain
+// that error; otherwise, err will contain the returned error.
+func (c *Client) RetrievePlanetFilms(id string, opts requestOpts) (filmsResp Response[swapi.Film], err error) {
+	return retrieveLinked[swapi.Film](c, "planets", id, "films", opts)
 }
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"github.com/pegondo/starwars-service/internal/resources/swapi"
+)
+
+// RetrieveSpecies calls the retrieve species endpoint from c.addr and returns
+// its response. If the response is valid, its data will be in speciesResp. If
+// the response is invalid and the response has errors.ResponseError format, err
+// will contain that error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveSpecies(opts requestOpts) (speciesResp Response[swapi.Species], err error) {
+	return retrieve[swapi.Species](c, "species", opts)
+}
+
+// RetrieveSpeciesById calls the retrieve species by id endpoint from c.addr
+// with the given options and returns its response. If the response is invalid
+// and the response has errors.ResponseError format, err will contain that
+// error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveSpeciesById(id string, opts requestOpts) (speciesResp ResourceResponse[swapi.Species], err error) {
+	return retrieveById[swapi.Species](c, "species", id, opts)
+}
+
+// RetrieveSpeciesPeople calls the endpoint from c.addr that returns the people
+// of the species with the given id, and returns its response. If the response
+// is invalid and the response has errors.ResponseError format, err will contain
+// that error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveSpeciesPeople(id string, opts requestOpts) (peopleResp Response[swapi.Person], err error) {
+	return retrieveLinked[swapi.Person](c, "species", id, "people", opts)
+}
+
+// RetrieveSpeciesFilms calls the endpoint from c.addr that returns the films of
+// the species with the given id, and returns its response. If the response is
+// invalid and the response has errors.ResponseError format, err will contain
+// that error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveSpeciesFilms(id string, opts requestOpts) (filmsResp Response[swapi.Film], err error) {
+	return retrieveLinked[swapi.Film](c, "species", id, "films", opts)
+}
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"github.com/pegondo/starwars-service/internal/resources/swapi"
+)
+
+// RetrieveStarships calls the retrieve starships endpoint from c.addr and returns
+// its response. If the response is valid, its data will be in starshipsResp. If
+// the response is invalid and the response has errors.ResponseError format, err
+// will contain that error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveStarships(opts requestOpts) (starshipsResp Response[swapi.Starship], err error) {
+	return retrieve[swapi.Starship](c, "starships", opts)
+}
+
+// RetrieveStarshipById calls the retrieve starship by id endpoint from c.addr
+// with the given options and returns its response. If the response is invalid
+// and the response has errors.ResponseError format, err will contain that
+// error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveStarshipById(id string, opts requestOpts) (starshipResp ResourceResponse[swapi.Starship], err error) {
+	return retrieveById[swapi.Starship](c, "starships", id, opts)
+}
+
+// RetrieveStarshipPilots calls the endpoint from c.addr that returns the pilots
+// of the starship with the given id, and returns its response. If the response
+// is invalid and the response has errors.ResponseError format, err will contain
+// that error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveStarshipPilots(id string, opts requestOpts) (pilotsResp Response[swapi.Person], err error) {
+	return retrieveLinked[swapi.Person](c, "starships", id, "pilots", opts)
+}
+
+// RetrieveStarshipFilms calls the endpoint from c.addr that returns the films
+// of the starship with the given id, and returns its response. If the response
+// is invalid and the response has errors.ResponseError format, err will contain
+// that error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveStarshipFilms(id string, opts requestOpts) (filmsResp Response[swapi.Film], err error) {
+	return retrieveLinked[swapi.Film](c, "starships", id, "films", opts)
+}
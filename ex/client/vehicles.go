@@ -0,0 +1,37 @@
+package client
+
+import (
+	"github.com/pegondo/starwars-service/internal/resources/swapi"
+)
+
+// RetrieveVehicles calls the retrieve vehicles endpoint from c.addr and returns
+// its response. If the response is valid, its data will be in vehiclesResp. If
+// the response is invalid and the response has errors.ResponseError format, err
+// will contain that error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveVehicles(opts requestOpts) (vehiclesResp Response[swapi.Vehicle], err error) {
+	return retrieve[swapi.Vehicle](c, "vehicles", opts)
+}
+
+// RetrieveVehicleById calls the retrieve vehicle by id endpoint from c.addr
+// with the given options and returns its response. If the response is invalid
+// and the response has errors.ResponseError format, err will contain that
+// error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveVehicleById(id string, opts requestOpts) (vehicleResp ResourceResponse[swapi.Vehicle], err error) {
+	return retrieveById[swapi.Vehicle](c, "vehicles", id, opts)
+}
+
+// RetrieveVehiclePilots calls the endpoint from c.addr that returns the pilots
+// of the vehicle with the given id, and returns its response. If the response
+// is invalid and the response has errors.ResponseError format, err will contain
+// that error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveVehiclePilots(id string, opts requestOpts) (pilotsResp Response[swapi.Person], err error) {
+	return retrieveLinked[swapi.Person](c, "vehicles", id, "pilots", opts)
+}
+
+// RetrieveVehicleFilms calls the endpoint from c.addr that returns the films of
+// the vehicle with the given id, and returns its response. If the response is
+// invalid and the response has errors.ResponseError format, err will contain
+// that error; otherwise, err will contain the returned error.
+func (c *Client) RetrieveVehicleFilms(id string, opts requestOpts) (filmsResp Response[swapi.Film], err error) {
+	return retrieveLinked[swapi.Film](c, "vehicles", id, "films", opts)
+}
@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Stats represents the usage statistics of a cache.
+type Stats struct {
+	// Hits is the number of lookups that found a fresh entry.
+	Hits uint64 `json:"hits"`
+	// Misses is the number of lookups that didn't find an entry or found an
+	// expired one.
+	Misses uint64 `json:"misses"`
+	// Size is the number of entries in the cache.
+	Size int `json:"size"`
+}
+
+// entry represents a value stored in the cache.
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// Cache is an in-memory cache safe for concurrent use. Its entries expire
+// after a fixed TTL and, when it's full, the least recently used entry is
+// evicted to make room for the new ones.
+type Cache[K comparable, V any] struct {
+	mu      sync.Mutex
+	maxSize int
+	ttl     time.Duration
+	// order holds the entries from the most to the least recently used.
+	order   *list.List
+	entries map[K]*list.Element
+	hits    uint64
+	misses  uint64
+	// now returns the current time. It's replaced in the tests.
+	now func() time.Time
+}
+
+// New creates and returns a cache with the given maximum number of entries and
+// TTL. If maxSize or ttl aren't greater than zero, the cache doesn't store any
+// entry.
+func New[K comparable, V any](maxSize int, ttl time.Duration) *Cache[K, V] {
+	return &Cache[K, V]{
+		maxSize: maxSize,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: map[K]*list.Element{},
+		now:     time.Now,
+	}
+}
+
+// Get returns the value stored with the given key. If there is no value or it
+// has expired, ok will be false.
+func (c *Cache[K, V]) Get(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, exists := c.entries[key]
+	if !exists {
+		c.misses++
+		return value, false
+	}
+	e := elem.Value.(*entry[K, V])
+	if !c.now().Before(e.expiresAt) {
+		c.removeElement(elem)
+		c.misses++
+		return value, false
+	}
+
+	c.order.MoveToFront(elem)
+	c.hits++
+	return e.value, true
+}
+
+// Set stores the value with the given key, replacing any previous value. If
+// the cache is full, Set evicts the least recently used entry.
+func (c *Cache[K, V]) Set(key K, value V) {
+	if c.maxSize <= 0 || c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(c.ttl)
+	if elem, exists := c.entries[key]; exists {
+		e := elem.Value.(*entry[K, V])
+		e.value = value
+		e.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	for c.order.Len() >= c.maxSize {
+		c.removeElement(c.order.Back())
+	}
+	c.entries[key] = c.order.PushFront(&entry[K, V]{
+		key:       key,
+		value:     value,
+		expiresAt: expiresAt,
+	})
+}
+
+// Stats returns the usage statistics of the cache.
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return Stats{
+		Hits:   c.hits,
+		Misses: c.misses,
+		Size:   c.order.Len(),
+	}
+}
+
+// removeElement removes the given element from the cache. The caller must hold
+// c.mu.
+func (c *Cache[K, V]) removeElement(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*entry[K, V]).key)
+}
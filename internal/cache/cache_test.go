@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	c := New[string, int](2, time.Minute)
+
+	_, ok := c.Get("<key>")
+	require.False(t, ok)
+
+	c.Set("<key>", 1)
+	value, ok := c.Get("<key>")
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+
+	c.Set("<key>", 2)
+	value, ok = c.Get("<key>")
+	require.True(t, ok)
+	require.Equal(t, 2, value)
+
+	require.Equal(t, Stats{Hits: 2, Misses: 1, Size: 1}, c.Stats())
+}
+
+func TestGet_Expired(t *testing.T) {
+	now := time.Now()
+	c := New[string, int](2, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set("<key>", 1)
+	now = now.Add(time.Minute)
+
+	_, ok := c.Get("<key>")
+	require.False(t, ok)
+	require.Equal(t, Stats{Hits: 0, Misses: 1, Size: 0}, c.Stats())
+}
+
+func TestSet_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := New[string, int](2, time.Minute)
+
+	c.Set("<key-1>", 1)
+	c.Set("<key-2>", 2)
+	// Use the first key so the second one is the least recently used.
+	_, ok := c.Get("<key-1>")
+	require.True(t, ok)
+	c.Set("<key-3>", 3)
+
+	_, ok = c.Get("<key-2>")
+	require.False(t, ok)
+	_, ok = c.Get("<key-1>")
+	require.True(t, ok)
+	_, ok = c.Get("<key-3>")
+	require.True(t, ok)
+	require.Equal(t, 2, c.Stats().Size)
+}
+
+func TestSet_Disabled(t *testing.T) {
+	testCases := []struct {
+		name    string
+		maxSize int
+		ttl     time.Duration
+	}{
+		{
+			name:    "zero_max_size",
+			maxSize: 0,
+			ttl:     time.Minute,
+		},
+		{
+			name:    "zero_ttl",
+			maxSize: 2,
+			ttl:     0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := New[string, int](tc.maxSize, tc.ttl)
+			c.Set("<key>", 1)
+
+			_, ok := c.Get("<key>")
+			require.False(t, ok)
+		})
+	}
+}
@@ -10,6 +10,37 @@ const (
 	InvalidPageSizeErrorCode = "INVALID_PAGE_SIZE"
 	InvalidPageSizeErrorMsg  = "The page size must be a number greater than 0."
 
+	InvalidSearchErrorCode = "INVALID_SEARCH"
+	InvalidSearchErrorMsg  = "The fuzzy search must be true or false."
+
 	InvalidSortCriteriaErrorCode = "INVALID_SORT_CRITERIA"
 	InvalidSortCriteriaErrorMsg  = "The sort criteria is invalid."
+
+	InvalidExpandErrorCode = "INVALID_EXPAND"
+	InvalidExpandErrorMsg  = "The fields to expand must be link fields of the resource."
+
+	InvalidFieldsErrorCode = "INVALID_FIELDS"
+	InvalidFieldsErrorMsg  = "The fields to respond with must be fields of the resource."
+
+	InvalidFilterErrorCode          = "INVALID_FILTER"
+	InvalidFilterErrorMsg           = "The filters must use fields of the resource, and the range filters numeric fields and values."
+	InvalidFilterExpressionErrorMsg = "The filter expression is invalid"
+
+	InvalidIdErrorCode = "INVALID_ID"
+	InvalidIdErrorMsg  = "The id must be a number greater than 0."
+
+	ResourceNotFoundErrorCode = "RESOURCE_NOT_FOUND"
+	ResourceNotFoundErrorMsg  = "The requested resource was not found."
+
+	UpstreamTimeoutErrorCode = "UPSTREAM_TIMEOUT"
+	UpstreamTimeoutErrorMsg  = "The upstream SWAPI didn't respond in time."
+
+	UpstreamUnavailableErrorCode = "UPSTREAM_UNAVAILABLE"
+	UpstreamUnavailableErrorMsg  = "The upstream SWAPI is unavailable, try again later."
+
+	UpstreamRateLimitedErrorCode = "UPSTREAM_RATE_LIMITED"
+	UpstreamRateLimitedErrorMsg  = "The upstream SWAPI rate limit was exceeded, try again later."
+
+	UpstreamErrorCode = "UPSTREAM_ERROR"
+	UpstreamErrorMsg  = "The upstream SWAPI responded with an error."
 )
@@ -0,0 +1,228 @@
+package filterexpr
+
+// Operator represents the comparison of a field with a value.
+type Operator string
+
+const (
+	// EqualOperator matches the fields equal to the value.
+	EqualOperator Operator = "eq"
+	// NotEqualOperator matches the fields that aren't equal to the value.
+	NotEqualOperator Operator = "ne"
+	// GreaterThanOperator matches the numeric fields greater than the value.
+	GreaterThanOperator Operator = "gt"
+	// GreaterOrEqualOperator matches the numeric fields greater than or equal
+	// to the value.
+	GreaterOrEqualOperator Operator = "gte"
+	// LessThanOperator matches the numeric fields less than the value.
+	LessThanOperator Operator = "lt"
+	// LessOrEqualOperator matches the numeric fields less than or equal to the
+	// value.
+	LessOrEqualOperator Operator = "lte"
+	// InOperator matches the fields equal to any of the values in a list.
+	InOperator Operator = "in"
+)
+
+// operators are the valid operators.
+var operators = []Operator{
+	EqualOperator,
+	NotEqualOperator,
+	GreaterThanOperator,
+	GreaterOrEqualOperator,
+	LessThanOperator,
+	LessOrEqualOperator,
+	InOperator,
+}
+
+// isRange returns whether the operator can only compare numbers.
+func (o Operator) isRange() bool {
+	switch o {
+	case GreaterThanOperator, GreaterOrEqualOperator, LessThanOperator, LessOrEqualOperator:
+		return true
+	default:
+		return false
+	}
+}
+
+// Type represents the type of a field.
+type Type int
+
+const (
+	// TextType is the type of the fields compared to strings.
+	TextType Type = iota
+	// NumberType is the type of the fields compared to numbers.
+	NumberType
+)
+
+// Schema maps the fields an expression can use to their types.
+type Schema map[string]Type
+
+// Record is what an expression is evaluated on, e.g. a resource.
+type Record interface {
+	// MatchesText returns whether the text field has the given lowercased
+	// value.
+	MatchesText(field, value string) bool
+	// Number returns the value of the numeric field, or false if it isn't
+	// known.
+	Number(field string) (float64, bool)
+}
+
+// Expr is a parsed filter expression.
+type Expr interface {
+	// Check validates that the expression only uses fields of the given
+	// schema and compares them to values of their types. If it doesn't,
+	// Check returns an *Error with the position of the offending token.
+	Check(schema Schema) error
+	// Eval returns whether the given record matches the expression. The
+	// comparisons of the numeric fields whose value isn't known don't match.
+	Eval(record Record) bool
+}
+
+// andExpr matches the records that match both of its expressions.
+type andExpr struct {
+	left, right Expr
+}
+
+// Check implements Expr.
+func (e andExpr) Check(schema Schema) error {
+	if err := e.left.Check(schema); err != nil {
+		return err
+	}
+	return e.right.Check(schema)
+}
+
+// Eval implements Expr.
+func (e andExpr) Eval(record Record) bool {
+	return e.left.Eval(record) && e.right.Eval(record)
+}
+
+// orExpr matches the records that match any of its expressions.
+type orExpr struct {
+	left, right Expr
+}
+
+// Check implements Expr.
+func (e orExpr) Check(schema Schema) error {
+	if err := e.left.Check(schema); err != nil {
+		return err
+	}
+	return e.right.Check(schema)
+}
+
+// Eval implements Expr.
+func (e orExpr) Eval(record Record) bool {
+	return e.left.Eval(record) || e.right.Eval(record)
+}
+
+// notExpr matches the records that don't match its expression.
+type notExpr struct {
+	expr Expr
+}
+
+// Check implements Expr.
+func (e notExpr) Check(schema Schema) error {
+	return e.expr.Check(schema)
+}
+
+// Eval implements Expr.
+func (e notExpr) Eval(record Record) bool {
+	return !e.expr.Eval(record)
+}
+
+// value is a literal of an expression.
+type value struct {
+	// text is the lowercased value of the string literals.
+	text string
+	// number is the value of the number literals.
+	number float64
+	// isNumber is whether the literal is a number.
+	isNumber bool
+	// pos is the 1-based position of the literal in the expression.
+	pos int
+}
+
+// comparison matches the records whose field compares to its values as its
+// operator requires.
+type comparison struct {
+	field    string
+	fieldPos int
+	op       Operator
+	opPos    int
+	values   []value
+}
+
+// Check implements Expr.
+func (c comparison) Check(schema Schema) error {
+	fieldType, ok := schema[c.field]
+	if !ok {
+		return newError(c.fieldPos, "unknown field %q", c.field)
+	}
+	if fieldType == TextType && c.op.isRange() {
+		return newError(c.opPos, "operator %s needs a numeric field, but %s is a text field", c.op, c.field)
+	}
+	for _, v := range c.values {
+		switch {
+		case fieldType == NumberType && !v.isNumber:
+			return newError(v.pos, "%s is a numeric field and must be compared to a number", c.field)
+		case fieldType == TextType && v.isNumber:
+			return newError(v.pos, "%s is a text field and must be compared to a string", c.field)
+		}
+	}
+	return nil
+}
+
+// Eval implements Expr.
+func (c comparison) Eval(record Record) bool {
+	if len(c.values) > 0 && c.values[0].isNumber {
+		return c.evalNumber(record)
+	}
+	return c.evalText(record)
+}
+
+// evalText evaluates the comparison of a text field.
+func (c comparison) evalText(record Record) bool {
+	switch c.op {
+	case EqualOperator, InOperator:
+		for _, v := range c.values {
+			if record.MatchesText(c.field, v.text) {
+				return true
+			}
+		}
+		return false
+	case NotEqualOperator:
+		return !record.MatchesText(c.field, c.values[0].text)
+	default:
+		return false
+	}
+}
+
+// evalNumber evaluates the comparison of a numeric field.
+func (c comparison) evalNumber(record Record) bool {
+	number, ok := record.Number(c.field)
+	if !ok {
+		return false
+	}
+	limit := c.values[0].number
+	switch c.op {
+	case EqualOperator:
+		return number == limit
+	case NotEqualOperator:
+		return number != limit
+	case GreaterThanOperator:
+		return number > limit
+	case GreaterOrEqualOperator:
+		return number >= limit
+	case LessThanOperator:
+		return number < limit
+	case LessOrEqualOperator:
+		return number <= limit
+	case InOperator:
+		for _, v := range c.values {
+			if number == v.number {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
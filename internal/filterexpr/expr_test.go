@@ -0,0 +1,145 @@
+package filterexpr
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testSchema is the schema of the testRecord fields.
+var testSchema = Schema{
+	"name":      TextType,
+	"gender":    TextType,
+	"eye_color": TextType,
+	"height":    NumberType,
+	"mass":      NumberType,
+}
+
+// testRecord is a record whose fields are stored in a map.
+type testRecord map[string]string
+
+func (r testRecord) MatchesText(field, value string) bool {
+	return strings.ToLower(r[field]) == value
+}
+
+func (r testRecord) Number(field string) (float64, bool) {
+	number, err := strconv.ParseFloat(r[field], 64)
+	return number, err == nil
+}
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		err   *Error
+	}{
+		{
+			name:  "valid",
+			input: `gender eq "female" and (height gt 170 or eye_color in ("blue", "green"))`,
+		},
+		{
+			name:  "unknown_field",
+			input: `gender eq "female" and climate eq "arid"`,
+			err:   &Error{Pos: 24, Msg: `unknown field "climate"`},
+		},
+		{
+			name:  "range_operator_on_text_field",
+			input: `name gt "m"`,
+			err:   &Error{Pos: 6, Msg: "operator gt needs a numeric field, but name is a text field"},
+		},
+		{
+			name:  "string_for_numeric_field",
+			input: `height eq "tall"`,
+			err:   &Error{Pos: 11, Msg: "height is a numeric field and must be compared to a number"},
+		},
+		{
+			name:  "number_for_text_field",
+			input: `eye_color in ("blue", 7)`,
+			err:   &Error{Pos: 23, Msg: "eye_color is a text field and must be compared to a string"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expr, err := Parse(tc.input)
+			require.NoError(t, err)
+			err = expr.Check(testSchema)
+			if tc.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func TestEval(t *testing.T) {
+	records := map[string]testRecord{
+		"leia":  {"name": "Leia Organa", "gender": "female", "eye_color": "brown", "height": "150", "mass": "49"},
+		"padme": {"name": "Padmé Amidala", "gender": "female", "eye_color": "brown", "height": "185", "mass": "45"},
+		"shmi":  {"name": "Shmi Skywalker", "gender": "female", "eye_color": "green", "height": "163", "mass": "unknown"},
+		"luke":  {"name": "Luke Skywalker", "gender": "male", "eye_color": "blue", "height": "172", "mass": "77"},
+	}
+
+	testCases := []struct {
+		name    string
+		input   string
+		matches []string
+	}{
+		{
+			name:    "nested",
+			input:   `gender eq "female" and (height gt 170 or eye_color in ("blue", "green"))`,
+			matches: []string{"padme", "shmi"},
+		},
+		{
+			name:    "or",
+			input:   `eye_color eq "Blue" or height lte 150`,
+			matches: []string{"leia", "luke"},
+		},
+		{
+			name:    "not",
+			input:   `not gender eq "female"`,
+			matches: []string{"luke"},
+		},
+		{
+			name:    "text_not_equal",
+			input:   `eye_color ne "brown"`,
+			matches: []string{"luke", "shmi"},
+		},
+		{
+			name:    "numeric_in",
+			input:   `height in (150, 172)`,
+			matches: []string{"leia", "luke"},
+		},
+		{
+			name:    "numeric_equal",
+			input:   `mass eq 49`,
+			matches: []string{"leia"},
+		},
+		{
+			name:    "unknown_numbers_dont_match",
+			input:   `mass lt 50 or mass ne 49`,
+			matches: []string{"leia", "luke", "padme"},
+		},
+		{
+			name:    "negated_unknown_numbers_match",
+			input:   `not mass gte 50`,
+			matches: []string{"leia", "padme", "shmi"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expr, err := Parse(tc.input)
+			require.NoError(t, err)
+			require.NoError(t, expr.Check(testSchema))
+
+			for name, record := range records {
+				require.Equal(t, slices.Contains(tc.matches, name), expr.Eval(record), name)
+			}
+		})
+	}
+}
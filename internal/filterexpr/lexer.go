@@ -0,0 +1,174 @@
+package filterexpr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind represents the kind of a token of an expression.
+type tokenKind int
+
+const (
+	// eofToken is the end of the expression.
+	eofToken tokenKind = iota
+	// identToken is a field name, an operator or a keyword, e.g. height, gt
+	// or and.
+	identToken
+	// stringToken is a double-quoted string literal, e.g. "blue".
+	stringToken
+	// numberToken is a number literal, e.g. 170 or -1.5.
+	numberToken
+	// lparenToken is an opening parenthesis.
+	lparenToken
+	// rparenToken is a closing parenthesis.
+	rparenToken
+	// commaToken is a comma, which separates the values of the in operator.
+	commaToken
+)
+
+// token is a token of an expression.
+type token struct {
+	kind tokenKind
+	// text is the text of the token. For strings, it's the unquoted value.
+	text string
+	// pos is the 1-based position of the first character of the token in the
+	// expression.
+	pos int
+}
+
+// String returns the description of the token used in the errors.
+func (t token) String() string {
+	if t.kind == eofToken {
+		return "the end of the expression"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// lexer splits an expression in tokens.
+type lexer struct {
+	input string
+	// offset is the byte offset of the next character to read.
+	offset int
+	// pos is the 1-based position of the next character to read.
+	pos int
+}
+
+// newLexer returns a lexer that splits the given expression.
+func newLexer(input string) *lexer {
+	return &lexer{input: input, pos: 1}
+}
+
+// peekRune returns the next character without reading it, or utf8.RuneError
+// if there are no more characters.
+func (l *lexer) peekRune() rune {
+	if l.offset >= len(l.input) {
+		return utf8.RuneError
+	}
+	r, _ := utf8.DecodeRuneInString(l.input[l.offset:])
+	return r
+}
+
+// readRune reads the next character.
+func (l *lexer) readRune() rune {
+	r, size := utf8.DecodeRuneInString(l.input[l.offset:])
+	l.offset += size
+	l.pos++
+	return r
+}
+
+// next returns the next token of the expression. If the expression has an
+// invalid token, next returns an error with its position.
+func (l *lexer) next() (token, error) {
+	for l.offset < len(l.input) && unicode.IsSpace(l.peekRune()) {
+		l.readRune()
+	}
+	pos := l.pos
+	if l.offset >= len(l.input) {
+		return token{kind: eofToken, pos: pos}, nil
+	}
+
+	r := l.peekRune()
+	switch {
+	case r == '(':
+		l.readRune()
+		return token{kind: lparenToken, text: "(", pos: pos}, nil
+	case r == ')':
+		l.readRune()
+		return token{kind: rparenToken, text: ")", pos: pos}, nil
+	case r == ',':
+		l.readRune()
+		return token{kind: commaToken, text: ",", pos: pos}, nil
+	case r == '"':
+		return l.readString()
+	case r == '-' || r == '+' || r == '.' || isDigit(r):
+		return l.readNumber()
+	case r == '_' || unicode.IsLetter(r):
+		start := l.offset
+		for l.offset < len(l.input) && isIdentRune(l.peekRune()) {
+			l.readRune()
+		}
+		return token{kind: identToken, text: l.input[start:l.offset], pos: pos}, nil
+	default:
+		return token{}, newError(pos, "unexpected character %q", r)
+	}
+}
+
+// readString reads a double-quoted string literal, where \" is a quote and \\
+// a backslash.
+func (l *lexer) readString() (token, error) {
+	pos := l.pos
+	l.readRune() // The opening quote.
+	var text strings.Builder
+	for l.offset < len(l.input) {
+		r := l.readRune()
+		switch r {
+		case '"':
+			return token{kind: stringToken, text: text.String(), pos: pos}, nil
+		case '\\':
+			escapePos := l.pos - 1
+			if l.offset >= len(l.input) {
+				return token{}, newError(pos, "unterminated string")
+			}
+			escaped := l.readRune()
+			if escaped != '"' && escaped != '\\' {
+				return token{}, newError(escapePos, "invalid escape sequence \\%c", escaped)
+			}
+			text.WriteRune(escaped)
+		default:
+			text.WriteRune(r)
+		}
+	}
+	return token{}, newError(pos, "unterminated string")
+}
+
+// readNumber reads a number literal, e.g. 170, -1.5 or 2e9.
+func (l *lexer) readNumber() (token, error) {
+	pos := l.pos
+	start := l.offset
+	for l.offset < len(l.input) && isNumberRune(l.peekRune()) {
+		l.readRune()
+	}
+	text := l.input[start:l.offset]
+	if _, ok := parseNumberLiteral(text); !ok {
+		return token{}, newError(pos, "invalid number %q", text)
+	}
+	return token{kind: numberToken, text: text, pos: pos}, nil
+}
+
+// isDigit returns whether r is an ASCII digit.
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// isIdentRune returns whether r can be part of a field name, an operator or a
+// keyword.
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isNumberRune returns whether r can be part of a number literal.
+func isNumberRune(r rune) bool {
+	return isDigit(r) || r == '.' || r == '-' || r == '+' || r == 'e' || r == 'E'
+}
@@ -0,0 +1,261 @@
+package filterexpr
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pegondo/starwars-service/internal/utils"
+)
+
+const (
+	// andKeyword joins two conditions that must both be met.
+	andKeyword = "and"
+	// orKeyword joins two conditions where at least one must be met.
+	orKeyword = "or"
+	// notKeyword negates a condition.
+	notKeyword = "not"
+)
+
+const (
+	// maxLength is the maximum length of an expression, in bytes.
+	maxLength = 2048
+	// maxDepth is the maximum number of nested parentheses and negations of an
+	// expression.
+	maxDepth = 32
+)
+
+// Error is the error returned when an expression isn't valid.
+type Error struct {
+	// Pos is the 1-based position of the offending token in the expression.
+	Pos int
+	// Msg describes the problem.
+	Msg string
+}
+
+func (err *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", err.Msg, err.Pos)
+}
+
+// newError returns an Error at the given position with the given formatted
+// message.
+func newError(pos int, format string, args ...any) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// parser builds the syntax tree of an expression from its tokens.
+type parser struct {
+	lexer *lexer
+	// tok is the current token.
+	tok token
+	// depth is the current number of nested parentheses and negations.
+	depth int
+}
+
+// Parse parses the given expression, e.g.
+//
+//	gender eq "female" and (height gt 170 or eye_color in ("blue", "green"))
+//
+// The conditions compare a field with eq, ne, gt, gte, lt or lte to a double
+// quoted string or a number, or check that it's one of a list of values with
+// in, and they are combined with and, or, not and parentheses. and binds
+// tighter than or. The keywords and operators ignore the case. If the
+// expression isn't valid, Parse returns an *Error with the position of the
+// offending token.
+func Parse(input string) (Expr, error) {
+	if len(input) > maxLength {
+		return nil, newError(maxLength+1, "the expression is longer than %d characters", maxLength)
+	}
+	p := &parser{lexer: newLexer(input)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == eofToken {
+		return nil, newError(p.tok.pos, "the expression is empty")
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != eofToken {
+		return nil, newError(p.tok.pos, "expected %s or %s, found %s", andKeyword, orKeyword, p.tok)
+	}
+	return expr, nil
+}
+
+// advance reads the next token.
+func (p *parser) advance() (err error) {
+	p.tok, err = p.lexer.next()
+	return err
+}
+
+// isKeyword returns whether the current token is the given keyword.
+func (p *parser) isKeyword(keyword string) bool {
+	return p.tok.kind == identToken && strings.EqualFold(p.tok.text, keyword)
+}
+
+// parseOr parses a list of conditions joined by or.
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword(orKeyword) {
+		if err = p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseAnd parses a list of conditions joined by and.
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword(andKeyword) {
+		if err = p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseUnary parses a condition, which may be negated or grouped between
+// parentheses.
+func (p *parser) parseUnary() (Expr, error) {
+	if p.isKeyword(notKeyword) || p.tok.kind == lparenToken {
+		if p.depth >= maxDepth {
+			return nil, newError(p.tok.pos, "the expression is nested more than %d levels", maxDepth)
+		}
+		p.depth++
+		defer func() { p.depth-- }()
+	}
+
+	switch {
+	case p.isKeyword(notKeyword):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr: expr}, nil
+	case p.tok.kind == lparenToken:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != rparenToken {
+			return nil, newError(p.tok.pos, "expected \")\", found %s", p.tok)
+		}
+		if err = p.advance(); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	default:
+		return p.parseComparison()
+	}
+}
+
+// parseComparison parses the comparison of a field with a value, e.g.
+// height gt 170, or with a list of values, e.g. eye_color in ("blue", "green").
+func (p *parser) parseComparison() (Expr, error) {
+	if p.tok.kind != identToken || isReserved(p.tok.text) {
+		return nil, newError(p.tok.pos, "expected a field, found %s", p.tok)
+	}
+	c := comparison{field: strings.ToLower(p.tok.text), fieldPos: p.tok.pos}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	c.op, c.opPos = Operator(strings.ToLower(p.tok.text)), p.tok.pos
+	if p.tok.kind != identToken || !slices.Contains(operators, c.op) {
+		return nil, newError(p.tok.pos, "expected an operator, found %s", p.tok)
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if c.op != InOperator {
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		c.values = []value{v}
+		return c, nil
+	}
+
+	if p.tok.kind != lparenToken {
+		return nil, newError(p.tok.pos, "expected \"(\", found %s", p.tok)
+	}
+	for {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		c.values = append(c.values, v)
+		if p.tok.kind == rparenToken {
+			break
+		}
+		if p.tok.kind != commaToken {
+			return nil, newError(p.tok.pos, "expected \",\" or \")\", found %s", p.tok)
+		}
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parseValue parses a string or a number.
+func (p *parser) parseValue() (value, error) {
+	v := value{pos: p.tok.pos}
+	switch p.tok.kind {
+	case stringToken:
+		v.text = utils.FoldCase(p.tok.text)
+	case numberToken:
+		v.number, _ = parseNumberLiteral(p.tok.text)
+		v.isNumber = true
+	default:
+		return v, newError(p.tok.pos, "expected a string or a number, found %s", p.tok)
+	}
+	return v, p.advance()
+}
+
+// isReserved returns whether the given identifier is a keyword or an
+// operator, so it can't be a field.
+func isReserved(ident string) bool {
+	ident = strings.ToLower(ident)
+	return ident == andKeyword || ident == orKeyword || ident == notKeyword ||
+		slices.Contains(operators, Operator(ident))
+}
+
+// parseNumberLiteral parses a finite number literal.
+func parseNumberLiteral(text string) (float64, bool) {
+	number, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsInf(number, 0) || math.IsNaN(number) {
+		return 0, false
+	}
+	return number, true
+}
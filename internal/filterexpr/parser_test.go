@@ -0,0 +1,215 @@
+package filterexpr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		expr  Expr
+	}{
+		{
+			name:  "comparison",
+			input: `height gt 170`,
+			expr: comparison{
+				field: "height", fieldPos: 1,
+				op: GreaterThanOperator, opPos: 8,
+				values: []value{{number: 170, isNumber: true, pos: 11}},
+			},
+		},
+		{
+			name:  "case_and_escapes",
+			input: `Name EQ "Obi-Wan \"Ben\" Kenobi"`,
+			expr: comparison{
+				field: "name", fieldPos: 1,
+				op: EqualOperator, opPos: 6,
+				values: []value{{text: `obi-wan "ben" kenobi`, pos: 9}},
+			},
+		},
+		{
+			name:  "in",
+			input: `eye_color in ("blue", "green")`,
+			expr: comparison{
+				field: "eye_color", fieldPos: 1,
+				op: InOperator, opPos: 11,
+				values: []value{{text: "blue", pos: 15}, {text: "green", pos: 23}},
+			},
+		},
+		{
+			name:  "and_binds_tighter_than_or",
+			input: `mass lt 50 or mass gt 100 and not height lte 150`,
+			expr: orExpr{
+				left: comparison{
+					field: "mass", fieldPos: 1,
+					op: LessThanOperator, opPos: 6,
+					values: []value{{number: 50, isNumber: true, pos: 9}},
+				},
+				right: andExpr{
+					left: comparison{
+						field: "mass", fieldPos: 15,
+						op: GreaterThanOperator, opPos: 20,
+						values: []value{{number: 100, isNumber: true, pos: 23}},
+					},
+					right: notExpr{expr: comparison{
+						field: "height", fieldPos: 35,
+						op: LessOrEqualOperator, opPos: 42,
+						values: []value{{number: 150, isNumber: true, pos: 46}},
+					}},
+				},
+			},
+		},
+		{
+			name:  "parentheses",
+			input: `(mass lt 50 or mass gt 100) and height ne -1.5`,
+			expr: andExpr{
+				left: orExpr{
+					left: comparison{
+						field: "mass", fieldPos: 2,
+						op: LessThanOperator, opPos: 7,
+						values: []value{{number: 50, isNumber: true, pos: 10}},
+					},
+					right: comparison{
+						field: "mass", fieldPos: 16,
+						op: GreaterThanOperator, opPos: 21,
+						values: []value{{number: 100, isNumber: true, pos: 24}},
+					},
+				},
+				right: comparison{
+					field: "height", fieldPos: 33,
+					op: NotEqualOperator, opPos: 40,
+					values: []value{{number: -1.5, isNumber: true, pos: 43}},
+				},
+			},
+		},
+		{
+			name:  "positions_count_characters",
+			input: `name eq "Padmé" or mass gt 1`,
+			expr: orExpr{
+				left: comparison{
+					field: "name", fieldPos: 1,
+					op: EqualOperator, opPos: 6,
+					values: []value{{text: "padmé", pos: 9}},
+				},
+				right: comparison{
+					field: "mass", fieldPos: 20,
+					op: GreaterThanOperator, opPos: 25,
+					values: []value{{number: 1, isNumber: true, pos: 28}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expr, err := Parse(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expr, expr)
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		err   *Error
+	}{
+		{
+			name:  "empty",
+			input: "  ",
+			err:   &Error{Pos: 3, Msg: "the expression is empty"},
+		},
+		{
+			name:  "missing_operator",
+			input: `height 170`,
+			err:   &Error{Pos: 8, Msg: `expected an operator, found "170"`},
+		},
+		{
+			name:  "unknown_operator",
+			input: `height like 170`,
+			err:   &Error{Pos: 8, Msg: `expected an operator, found "like"`},
+		},
+		{
+			name:  "missing_value",
+			input: `height gt`,
+			err:   &Error{Pos: 10, Msg: "expected a string or a number, found the end of the expression"},
+		},
+		{
+			name:  "keyword_as_field",
+			input: `and eq "x"`,
+			err:   &Error{Pos: 1, Msg: `expected a field, found "and"`},
+		},
+		{
+			name:  "unclosed_parenthesis",
+			input: `(height gt 170`,
+			err:   &Error{Pos: 15, Msg: `expected ")", found the end of the expression`},
+		},
+		{
+			name:  "unexpected_parenthesis",
+			input: `height gt 170)`,
+			err:   &Error{Pos: 14, Msg: `expected and or or, found ")"`},
+		},
+		{
+			name:  "unterminated_string",
+			input: `name eq "luke`,
+			err:   &Error{Pos: 9, Msg: "unterminated string"},
+		},
+		{
+			name:  "invalid_escape",
+			input: `name eq "lu\ke"`,
+			err:   &Error{Pos: 12, Msg: `invalid escape sequence \k`},
+		},
+		{
+			name:  "invalid_number",
+			input: `height gt 1.2.3`,
+			err:   &Error{Pos: 11, Msg: `invalid number "1.2.3"`},
+		},
+		{
+			name:  "infinite_number",
+			input: `height gt 1e999`,
+			err:   &Error{Pos: 11, Msg: `invalid number "1e999"`},
+		},
+		{
+			name:  "unexpected_character",
+			input: `height >= 170`,
+			err:   &Error{Pos: 8, Msg: `unexpected character '>'`},
+		},
+		{
+			name:  "in_without_list",
+			input: `eye_color in "blue"`,
+			err:   &Error{Pos: 14, Msg: `expected "(", found "blue"`},
+		},
+		{
+			name:  "in_missing_comma",
+			input: `eye_color in ("blue" "green")`,
+			err:   &Error{Pos: 22, Msg: `expected "," or ")", found "green"`},
+		},
+		{
+			name:  "too_deep",
+			input: strings.Repeat("(", maxDepth+1) + "height gt 1" + strings.Repeat(")", maxDepth+1),
+			err:   &Error{Pos: maxDepth + 1, Msg: "the expression is nested more than 32 levels"},
+		},
+		{
+			name:  "too_long",
+			input: `name eq "` + strings.Repeat("a", maxLength) + `"`,
+			err:   &Error{Pos: maxLength + 1, Msg: "the expression is longer than 2048 characters"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.input)
+			require.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	err := &Error{Pos: 8, Msg: `expected an operator, found "170"`}
+	require.Equal(t, `expected an operator, found "170" at position 8`, err.Error())
+}
@@ -0,0 +1,159 @@
+package fulltext
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pegondo/starwars-service/internal/utils"
+)
+
+const (
+	// defaultBoost is the weight of the fields without a boost.
+	defaultBoost = 1.0
+	// prefixMatchWeight is the weight of the words that start with a query
+	// term, relative to the words equal to it.
+	prefixMatchWeight = 0.5
+	// scorePrecision is the number of decimals of the scores.
+	scorePrecision = 3
+)
+
+// Document is a document to index, made of named text fields.
+type Document map[string]string
+
+// Match is a document that matches a query.
+type Match struct {
+	// Doc is the position of the document in the indexed documents.
+	Doc int
+	// Score is the relevance of the document for the query. The higher, the
+	// more relevant.
+	Score float64
+}
+
+// posting records the occurrences of a word in a document.
+type posting struct {
+	doc int
+	// weight is the sum of the boosts of the fields where the word appears,
+	// once per occurrence.
+	weight float64
+}
+
+// Index is an inverted index of documents that finds the documents containing
+// the words of a query and ranks them by relevance. It's safe for concurrent
+// searches once built.
+type Index struct {
+	// postings maps each word to its occurrences, sorted by document.
+	postings map[string][]posting
+	// words are the indexed words, sorted, to find the words with a prefix.
+	words []string
+	// docs is the number of indexed documents.
+	docs int
+}
+
+// Tokenize splits the given text in case folded words made of letters and
+// digits.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(utils.FoldCase(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Build returns the index of the given documents. The words in the fields with
+// a boost weigh that boost in the score instead of 1, e.g. to rank the
+// documents whose name matches the query first.
+func Build(docs []Document, boosts map[string]float64) *Index {
+	idx := &Index{postings: map[string][]posting{}, docs: len(docs)}
+	for i, doc := range docs {
+		weights := map[string]float64{}
+		for field, text := range doc {
+			boost, ok := boosts[field]
+			if !ok {
+				boost = defaultBoost
+			}
+			for _, word := range Tokenize(text) {
+				weights[word] += boost
+			}
+		}
+		for word, weight := range weights {
+			idx.postings[word] = append(idx.postings[word], posting{doc: i, weight: weight})
+		}
+	}
+	idx.words = make([]string, 0, len(idx.postings))
+	for word := range idx.postings {
+		idx.words = append(idx.words, word)
+	}
+	slices.Sort(idx.words)
+	return idx
+}
+
+// Search returns the documents that contain all the words of the given query,
+// or words that start with them, sorted by score from the most to the least
+// relevant and then by position. The score of a document adds, for each query
+// word, the weight of its occurrences in the document damped logarithmically
+// and multiplied by how rare the word is in the index. The words that only
+// start with a query word weigh half. If the query has no words, Search
+// returns nil.
+func (idx *Index) Search(query string) []Match {
+	terms := Tokenize(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var scores map[int]float64
+	for _, term := range slices.Compact(slices.Sorted(slices.Values(terms))) {
+		termScores := idx.termScores(term)
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+		// Keep only the documents that match all the terms.
+		for doc, score := range scores {
+			termScore, ok := termScores[doc]
+			if !ok {
+				delete(scores, doc)
+				continue
+			}
+			scores[doc] = score + termScore
+		}
+	}
+
+	matches := make([]Match, 0, len(scores))
+	for doc, score := range scores {
+		matches = append(matches, Match{Doc: doc, Score: roundScore(score)})
+	}
+	slices.SortFunc(matches, func(a, b Match) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Doc, b.Doc))
+	})
+	return matches
+}
+
+// termScores returns the score of the given query term in each document that
+// contains it or a word that starts with it.
+func (idx *Index) termScores(term string) map[int]float64 {
+	scores := map[int]float64{}
+	first := sort.SearchStrings(idx.words, term)
+	for _, word := range idx.words[first:] {
+		if !strings.HasPrefix(word, term) {
+			break
+		}
+		matchWeight := 1.0
+		if word != term {
+			matchWeight = prefixMatchWeight
+		}
+		postings := idx.postings[word]
+		idf := math.Log(1 + float64(idx.docs)/float64(len(postings)))
+		for _, p := range postings {
+			scores[p.doc] += matchWeight * math.Log1p(p.weight) * idf
+		}
+	}
+	return scores
+}
+
+// roundScore rounds the given score to scorePrecision decimals.
+func roundScore(score float64) float64 {
+	factor := math.Pow10(scorePrecision)
+	return math.Round(score*factor) / factor
+}
@@ -0,0 +1,120 @@
+package fulltext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	testCases := []struct {
+		name  string
+		text  string
+		words []string
+	}{
+		{
+			name:  "empty",
+			text:  "",
+			words: []string{},
+		},
+		{
+			name:  "punctuation_and_case",
+			text:  "Obi-Wan Kenobi, a Jedi!",
+			words: []string{"obi", "wan", "kenobi", "a", "jedi"},
+		},
+		{
+			name:  "digits_and_accents",
+			text:  "R2-D2 met Padmé",
+			words: []string{"r2", "d2", "met", "padmé"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.words, Tokenize(tc.text))
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	docs := []Document{
+		{"name": "Tatooine", "climate": "arid", "terrain": "desert"},
+		{"name": "Hoth", "climate": "frozen", "terrain": "tundra, ice caves, mountain ranges"},
+		{"name": "Jakku", "climate": "arid", "terrain": "desert, deserts"},
+		{"name": "Desert Moon", "climate": "temperate", "terrain": "forests"},
+	}
+	idx := Build(docs, map[string]float64{"name": 3})
+
+	testCases := []struct {
+		name  string
+		query string
+		docs  []int
+	}{
+		{
+			name:  "empty_query",
+			query: " ,; ",
+			docs:  nil,
+		},
+		{
+			name:  "no_match",
+			query: "ocean",
+			docs:  []int{},
+		},
+		{
+			name:  "boosted_field_ranks_first",
+			query: "desert",
+			docs:  []int{3, 2, 0},
+		},
+		{
+			name:  "all_terms_must_match",
+			query: "arid desert",
+			docs:  []int{2, 0},
+		},
+		{
+			name:  "prefix",
+			query: "MOUNT",
+			docs:  []int{1},
+		},
+		{
+			name:  "repeated_terms",
+			query: "hoth hoth",
+			docs:  []int{1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matches := idx.Search(tc.query)
+			if tc.docs == nil {
+				require.Nil(t, matches)
+				return
+			}
+			docs := []int{}
+			for _, match := range matches {
+				require.Greater(t, match.Score, 0.0)
+				docs = append(docs, match.Doc)
+			}
+			require.Equal(t, tc.docs, docs)
+		})
+	}
+}
+
+func TestSearch_Scores(t *testing.T) {
+	docs := []Document{
+		{"name": "Hoth", "climate": "frozen"},
+		{"name": "Ilum", "climate": "frozen, frozen"},
+		{"name": "Tatooine", "climate": "arid"},
+		{"name": "Frozenia", "climate": "cold"},
+	}
+	idx := Build(docs, nil)
+
+	matches := idx.Search("frozen")
+	require.Len(t, matches, 3)
+	// More occurrences score higher, and prefix matches score half.
+	require.Equal(t, 1, matches[0].Doc)
+	require.Equal(t, 0, matches[1].Doc)
+	require.Equal(t, 3, matches[2].Doc)
+	require.Greater(t, matches[0].Score, matches[1].Score)
+	require.Greater(t, matches[1].Score, matches[2].Score)
+	require.Equal(t, roundScore(matches[1].Score), matches[1].Score)
+}
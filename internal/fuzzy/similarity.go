@@ -0,0 +1,71 @@
+package fuzzy
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pegondo/starwars-service/internal/utils"
+)
+
+// Distance returns the edit distance between a and b, i.e. the number of
+// characters that must be inserted, deleted, substituted or transposed with
+// the next one to turn a into b. Each character is only edited once, so
+// Distance is the optimal string alignment distance.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// Only the last three rows of the distance matrix are needed: the
+	// current one, the previous one and, for the transpositions, the one
+	// before.
+	beforePrev := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], beforePrev[j-2]+1)
+			}
+		}
+		beforePrev, prev, cur = prev, cur, beforePrev
+	}
+	return prev[len(rb)]
+}
+
+// Similarity returns how similar the given query is to the given text, from 0
+// to 1, ignoring the case and the Unicode normalization form. It's 1 if the
+// text contains the query and, otherwise, the highest similarity by edit
+// distance of the query with the whole text or with any run of consecutive
+// words of the text with as many words as the query, so "skywaker" is similar
+// to "Luke Skywalker".
+func Similarity(query, text string) float64 {
+	query, text = utils.FoldCase(query), utils.FoldCase(text)
+	if strings.Contains(text, query) {
+		return 1
+	}
+
+	best := distanceSimilarity(query, text)
+	numQueryWords := len(strings.Fields(query))
+	words := strings.Fields(text)
+	for i := 0; i+numQueryWords <= len(words); i++ {
+		best = max(best, distanceSimilarity(query, strings.Join(words[i:i+numQueryWords], " ")))
+	}
+	return best
+}
+
+// distanceSimilarity returns the similarity of a and b by their edit distance,
+// from 0 if all their characters must be edited to 1 if they are equal.
+func distanceSimilarity(a, b string) float64 {
+	longest := max(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(Distance(a, b))/float64(longest)
+}
@@ -0,0 +1,83 @@
+package fuzzy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistance(t *testing.T) {
+	testCases := []struct {
+		a        string
+		b        string
+		distance int
+	}{
+		{a: "", b: "", distance: 0},
+		{a: "", b: "yoda", distance: 4},
+		{a: "yoda", b: "yoda", distance: 0},
+		{a: "skywaker", b: "skywalker", distance: 1},
+		{a: "dagoba", b: "dagobah", distance: 1},
+		{a: "hoht", b: "hoth", distance: 1},
+		{a: "padme", b: "padmé", distance: 1},
+		{a: "luke", b: "leia", distance: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.a+"_"+tc.b, func(t *testing.T) {
+			require.Equal(t, tc.distance, Distance(tc.a, tc.b))
+			require.Equal(t, tc.distance, Distance(tc.b, tc.a))
+		})
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	testCases := []struct {
+		name       string
+		query      string
+		text       string
+		similarity float64
+	}{
+		{
+			name:       "contained",
+			query:      "sky",
+			text:       "Luke Skywalker",
+			similarity: 1,
+		},
+		{
+			name:       "typo_in_a_word",
+			query:      "skywaker",
+			text:       "Luke Skywalker",
+			similarity: 1 - 1.0/9,
+		},
+		{
+			name:       "typo_in_many_words",
+			query:      "luke skywaker",
+			text:       "Luke Skywalker",
+			similarity: 1 - 1.0/14,
+		},
+		{
+			name:       "accents_and_case",
+			query:      "PADME\u0301",
+			text:       "Padmé Amidala",
+			similarity: 1,
+		},
+		{
+			name:       "whole_text",
+			query:      "r2d2",
+			text:       "R2-D2",
+			similarity: 1 - 1.0/5,
+		},
+		{
+			name:       "different",
+			query:      "yoda",
+			text:       "Hoth",
+			similarity: 0.25,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.InDelta(t, tc.similarity, Similarity(tc.query, tc.text), 1e-9)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/pegondo/starwars-service/internal/utils"
+)
+
+const (
+	// defaultPort is the port the service listens on if PORT isn't defined, as
+	// in gin.
+	defaultPort = "8080"
+	// defaultRequestDeadline is the maximum time to spend serving a request if
+	// REQUEST_DEADLINE isn't defined.
+	defaultRequestDeadline = time.Minute
+)
+
+// publicBaseUrl is the public base URL of the service API, e.g.
+// "https://starwars.example.com/api", which the resource URLs in the responses
+// point to. If PUBLIC_BASE_URL isn't defined, it's the local address the
+// service listens on, as the Host header of the requests is set by the clients
+// and can't be trusted.
+var publicBaseUrl = utils.EnvString("PUBLIC_BASE_URL", "http://localhost:"+utils.EnvString("PORT", defaultPort)+ApiBasePath)
+
+// requestDeadline is the maximum time to spend serving a request, including
+// all the requests to SWAPI it needs, such as the pages of a sorted collection
+// or the resources to expand. 0 disables it.
+var requestDeadline = utils.EnvDuration("REQUEST_DEADLINE", defaultRequestDeadline)
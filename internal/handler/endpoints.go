@@ -1,8 +1,86 @@
 package handler
 
 const (
+	// ApiBasePath is the path the endpoints are served under.
+	ApiBasePath = "/api"
+
+	// HealthEndpoint is the name of the health check endpoint.
+	HealthEndpoint = "/health"
 	// PeopleEndpoint is the name of the people endpoint.
 	PeopleEndpoint = "/people"
 	// PlanetEndpoint is the name of the planets endpoint.
 	PlanetEndpoint = "/planets"
+	// FilmsEndpoint is the name of the films endpoint.
+	FilmsEndpoint = "/films"
+	// SpeciesEndpoint is the name of the species endpoint.
+	SpeciesEndpoint = "/species"
+	// VehiclesEndpoint is the name of the vehicles endpoint.
+	VehiclesEndpoint = "/vehicles"
+	// StarshipsEndpoint is the name of the starships endpoint.
+	StarshipsEndpoint = "/starships"
+
+	// PeopleByIdEndpoint is the name of the endpoint for a single person.
+	PeopleByIdEndpoint = PeopleEndpoint + "/:id"
+	// PlanetByIdEndpoint is the name of the endpoint for a single planet.
+	PlanetByIdEndpoint = PlanetEndpoint + "/:id"
+	// FilmsByIdEndpoint is the name of the endpoint for a single film.
+	FilmsByIdEndpoint = FilmsEndpoint + "/:id"
+	// SpeciesByIdEndpoint is the name of the endpoint for a single species.
+	SpeciesByIdEndpoint = SpeciesEndpoint + "/:id"
+	// VehiclesByIdEndpoint is the name of the endpoint for a single vehicle.
+	VehiclesByIdEndpoint = VehiclesEndpoint + "/:id"
+	// StarshipsByIdEndpoint is the name of the endpoint for a single starship.
+	StarshipsByIdEndpoint = StarshipsEndpoint + "/:id"
+
+	// PersonFilmsEndpoint is the name of the endpoint for the films of a
+	// person.
+	PersonFilmsEndpoint = PeopleByIdEndpoint + "/films"
+	// PersonSpeciesEndpoint is the name of the endpoint for the species of a
+	// person.
+	PersonSpeciesEndpoint = PeopleByIdEndpoint + "/species"
+	// PersonVehiclesEndpoint is the name of the endpoint for the vehicles of a
+	// person.
+	PersonVehiclesEndpoint = PeopleByIdEndpoint + "/vehicles"
+	// PersonStarshipsEndpoint is the name of the endpoint for the starships of
+	// a person.
+	PersonStarshipsEndpoint = PeopleByIdEndpoint + "/starships"
+	// PlanetResidentsEndpoint is the name of the endpoint for the residents of
+	// a planet.
+	PlanetResidentsEndpoint = PlanetByIdEndpoint + "/residents"
+	// PlanetFilmsEndpoint is the name of the endpoint for the films of a
+	// planet.
+	PlanetFilmsEndpoint = PlanetByIdEndpoint + "/films"
+	// FilmCharactersEndpoint is the name of the endpoint for the characters of
+	// a film.
+	FilmCharactersEndpoint = FilmsByIdEndpoint + "/characters"
+	// FilmPlanetsEndpoint is the name of the endpoint for the planets of a
+	// film.
+	FilmPlanetsEndpoint = FilmsByIdEndpoint + "/planets"
+	// FilmStarshipsEndpoint is the name of the endpoint for the starships of a
+	// film.
+	FilmStarshipsEndpoint = FilmsByIdEndpoint + "/starships"
+	// FilmVehiclesEndpoint is the name of the endpoint for the vehicles of a
+	// film.
+	FilmVehiclesEndpoint = FilmsByIdEndpoint + "/vehicles"
+	// FilmSpeciesEndpoint is the name of the endpoint for the species of a
+	// film.
+	FilmSpeciesEndpoint = FilmsByIdEndpoint + "/species"
+	// SpeciesPeopleEndpoint is the name of the endpoint for the people of a
+	// species.
+	SpeciesPeopleEndpoint = SpeciesByIdEndpoint + "/people"
+	// SpeciesFilmsEndpoint is the name of the endpoint for the films of a
+	// species.
+	SpeciesFilmsEndpoint = SpeciesByIdEndpoint + "/films"
+	// VehiclePilotsEndpoint is the name of the endpoint for the pilots of a
+	// vehicle.
+	VehiclePilotsEndpoint = VehiclesByIdEndpoint + "/pilots"
+	// VehicleFilmsEndpoint is the name of the endpoint for the films of a
+	// vehicle.
+	VehicleFilmsEndpoint = VehiclesByIdEndpoint + "/films"
+	// StarshipPilotsEndpoint is the name of the endpoint for the pilots of a
+	// starship.
+	StarshipPilotsEndpoint = StarshipsByIdEndpoint + "/pilots"
+	// StarshipFilmsEndpoint is the name of the endpoint for the films of a
+	// starship.
+	StarshipFilmsEndpoint = StarshipsByIdEndpoint + "/films"
 )
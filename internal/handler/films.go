@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"github.com/pegondo/starwars-service/internal/resources/swapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// retrieveFilmsHandlerName is the name of the retrieve films handler.
+	retrieveFilmsHandlerName = "retrieve films"
+	// retrieveFilmByIdHandlerName is the name of the retrieve film by id
+	// handler.
+	retrieveFilmByIdHandlerName = "retrieve film by id"
+	// retrieveFilmCharactersHandlerName is the name of the retrieve film
+	// characters handler.
+	retrieveFilmCharactersHandlerName = "retrieve film characters"
+	// retrieveFilmPlanetsHandlerName is the name of the retrieve film planets
+	// handler.
+	retrieveFilmPlanetsHandlerName = "retrieve film planets"
+	// retrieveFilmStarshipsHandlerName is the name of the retrieve film
+	// starships handler.
+	retrieveFilmStarshipsHandlerName = "retrieve film starships"
+	// retrieveFilmVehiclesHandlerName is the name of the retrieve film vehicles
+	// handler.
+	retrieveFilmVehiclesHandlerName = "retrieve film vehicles"
+	// retrieveFilmSpeciesHandlerName is the name of the retrieve film species
+	// handler.
+	retrieveFilmSpeciesHandlerName = "retrieve film species"
+)
+
+// RetrieveFilms handles the requests to retrieve the films collection.
+func (h *Handler) RetrieveFilms(c *gin.Context) {
+	retrieveResources(c, h, retrieveFilmsHandlerName, swapi.RetrieveFilms)
+}
+
+// RetrieveFilmById handles the requests to retrieve a single film by its id.
+func (h *Handler) RetrieveFilmById(c *gin.Context) {
+	retrieveResource(c, h, retrieveFilmByIdHandlerName, swapi.RetrieveFilmById)
+}
+
+// RetrieveFilmCharacters handles the requests to retrieve the characters of a
+// film.
+func (h *Handler) RetrieveFilmCharacters(c *gin.Context) {
+	retrieveLinkedResources(c, h, retrieveFilmCharactersHandlerName, swapi.RetrieveFilmCharacters)
+}
+
+// RetrieveFilmPlanets handles the requests to retrieve the planets of a film.
+func (h *Handler) RetrieveFilmPlanets(c *gin.Context) {
+	retrieveLinkedResources(c, h, retrieveFilmPlanetsHandlerName, swapi.RetrieveFilmPlanets)
+}
+
+// RetrieveFilmStarships handles the requests to retrieve the starships of a
+// film.
+func (h *Handler) RetrieveFilmStarships(c *gin.Context) {
+	retrieveLinkedResources(c, h, retrieveFilmStarshipsHandlerName, swapi.RetrieveFilmStarships)
+}
+
+// RetrieveFilmVehicles handles the requests to retrieve the vehicles of a film.
+func (h *Handler) RetrieveFilmVehicles(c *gin.Context) {
+	retrieveLinkedResources(c, h, retrieveFilmVehiclesHandlerName, swapi.RetrieveFilmVehicles)
+}
+
+// RetrieveFilmSpecies handles the requests to retrieve the species of a film.
+func (h *Handler) RetrieveFilmSpecies(c *gin.Context) {
+	retrieveLinkedResources(c, h, retrieveFilmSpeciesHandlerName, swapi.RetrieveFilmSpecies)
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/pegondo/starwars-service/internal/resources/swapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler handles the requests to the resource endpoints, retrieving the
+// resources from its backends.
+type Handler struct {
+	// backends are the sources the resources are retrieved from.
+	backends swapi.Backends
+	// deadline is the maximum time to spend serving a request. If it's 0, the
+	// requests aren't bounded.
+	deadline time.Duration
+}
+
+// New creates and returns a handler that retrieves the resources from the
+// given backends, spending at most requestDeadline on each request.
+func New(backends swapi.Backends) *Handler {
+	return &Handler{
+		backends: backends,
+		deadline: requestDeadline,
+	}
+}
+
+// requestContext returns the context to retrieve the resources of the given
+// request with, which retrieves them from the handler backends and records
+// the SWAPI mirrors that serve them. The context is done when the deadline of
+// the handler is exceeded, and the returned cancel function must be called
+// once the resources are retrieved.
+func (h *Handler) requestContext(c *gin.Context) (context.Context, context.CancelFunc) {
+	ctx := swapi.WithBackends(swapi.WithServedBy(c.Request.Context()), h.backends)
+	if h.deadline <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, h.deadline)
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/pegondo/starwars-service/internal/cache"
+	"github.com/pegondo/starwars-service/internal/resources/swapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// healthyStatus is the status of the service when it can serve requests.
+	healthyStatus = "ok"
+	// degradedStatus is the status of the service when SWAPI is unavailable.
+	degradedStatus = "degraded"
+)
+
+// HealthResponse represents the response of the health handler.
+type HealthResponse struct {
+	// Status is the status of the service.
+	Status string `json:"status"`
+	// Swapi is the state of the circuit breakers around the SWAPI mirrors as a
+	// whole.
+	Swapi swapi.CircuitState `json:"swapi"`
+	// Mirrors are the statuses of the SWAPI mirrors, in order of preference.
+	Mirrors []swapi.UpstreamStatus `json:"mirrors"`
+	// Cache are the usage statistics of the SWAPI responses cache, to observe
+	// how many requests it saves.
+	Cache cache.Stats `json:"cache"`
+}
+
+// Health handles the health check requests, which also report the SWAPI
+// responses cache statistics. If the circuit breakers around every SWAPI mirror
+// are open, the service is degraded and Health responds with a 503.
+func Health(c *gin.Context) {
+	state := swapi.BreakerState()
+	mirrors := swapi.UpstreamStatuses()
+	cacheStats := swapi.CacheStats()
+	if state == swapi.CircuitOpen {
+		c.JSON(http.StatusServiceUnavailable, HealthResponse{
+			Status:  degradedStatus,
+			Swapi:   state,
+			Mirrors: mirrors,
+			Cache:   cacheStats,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, HealthResponse{
+		Status:  healthyStatus,
+		Swapi:   state,
+		Mirrors: mirrors,
+		Cache:   cacheStats,
+	})
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pegondo/starwars-service/internal/resources/swapi"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth(t *testing.T) {
+	r := gin.New()
+	r.GET(HealthEndpoint, Health)
+
+	req, err := http.NewRequest("GET", HealthEndpoint, nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp HealthResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, HealthResponse{
+		Status:  healthyStatus,
+		Swapi:   swapi.CircuitClosed,
+		Mirrors: swapi.UpstreamStatuses(),
+		Cache:   swapi.CacheStats(),
+	}, resp)
+	require.Len(t, resp.Mirrors, 1)
+}
+
+func TestHealth_CacheStats(t *testing.T) {
+	r := gin.New()
+	r.GET(HealthEndpoint, Health)
+
+	req, err := http.NewRequest("GET", HealthEndpoint, nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Cache map[string]any `json:"cache"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	stats := swapi.CacheStats()
+	require.Equal(t, map[string]any{
+		"hits":   float64(stats.Hits),
+		"misses": float64(stats.Misses),
+		"size":   float64(stats.Size),
+	}, resp.Cache)
+}
@@ -1,42 +1,61 @@
 package handler
 
 import (
-	"net/http"
-
-	"github.com/pegondo/starwars-service/internal/errors"
-	"github.com/pegondo/starwars-service/internal/logger"
-	"github.com/pegondo/starwars-service/internal/request"
 	"github.com/pegondo/starwars-service/internal/resources/swapi"
 
 	"github.com/gin-gonic/gin"
 )
 
-// retrievePeopleHandlerName is the name of the retrieve people handler.
-const retrievePeopleHandlerName = "retrieve people"
-
-func RetrievePeople(c *gin.Context) {
-	l := logger.Logger(c)
-	l.Info().Msgf("received request to the %s endpoint", retrievePeopleHandlerName)
-
-	params, err := request.Params(c)
-	if err != nil {
-		l.Warn().Msgf("invalid request parameters :: %v", err)
-		c.AbortWithError(http.StatusBadRequest, err)
-		return
-	}
-
-	people, err := swapi.RetrievePeople(params)
-	if err != nil {
-		// If there is an issue while requesting for the people, return a 500.
-		l.Error().Msg(err.Error())
-		err = errors.New(errors.InternalServerErrorCode, errors.InternalServerErrorMsg)
-		c.AbortWithError(http.StatusInternalServerError, err)
-		return
-	}
-
-	statusCode := getStatusCode(people)
-	c.JSON(statusCode, Response[swapi.Person]{
-		Data:  people.Results,
-		Count: people.Count,
-	})
+const (
+	// retrievePeopleHandlerName is the name of the retrieve people handler.
+	retrievePeopleHandlerName = "retrieve people"
+	// retrievePersonByIdHandlerName is the name of the retrieve person by id
+	// handler.
+	retrievePersonByIdHandlerName = "retrieve person by id"
+	// retrievePersonFilmsHandlerName is the name of the retrieve person films
+	// handler.
+	retrievePersonFilmsHandlerName = "retrieve person films"
+	// retrievePersonSpeciesHandlerName is the name of the retrieve person
+	// species handler.
+	retrievePersonSpeciesHandlerName = "retrieve person species"
+	// retrievePersonVehiclesHandlerName is the name of the retrieve person
+	// vehicles handler.
+	retrievePersonVehiclesHandlerName = "retrieve person vehicles"
+	// retrievePersonStarshipsHandlerName is the name of the retrieve person
+	// starships handler.
+	retrievePersonStarshipsHandlerName = "retrieve person starships"
+)
+
+// RetrievePeople handles the requests to retrieve the people collection.
+func (h *Handler) RetrievePeople(c *gin.Context) {
+	retrieveResources(c, h, retrievePeopleHandlerName, swapi.RetrievePeople)
+}
+
+// RetrievePersonById handles the requests to retrieve a single person by its
+// id.
+func (h *Handler) RetrievePersonById(c *gin.Context) {
+	retrieveResource(c, h, retrievePersonByIdHandlerName, swapi.RetrievePersonById)
+}
+
+// RetrievePersonFilms handles the requests to retrieve the films of a person.
+func (h *Handler) RetrievePersonFilms(c *gin.Context) {
+	retrieveLinkedResources(c, h, retrievePersonFilmsHandlerName, swapi.RetrievePersonFilms)
+}
+
+// RetrievePersonSpecies handles the requests to retrieve the species of a
+// person.
+func (h *Handler) RetrievePersonSpecies(c *gin.Context) {
+	retrieveLinkedResources(c, h, retrievePersonSpeciesHandlerName, swapi.RetrievePersonSpecies)
+}
+
+// RetrievePersonVehicles handles the requests to retrieve the vehicles of a
+// person.
+func (h *Handler) RetrievePersonVehicles(c *gin.Context) {
+	retrieveLinkedResources(c, h, retrievePersonVehiclesHandlerName, swapi.RetrievePersonVehicles)
+}
+
+// RetrievePersonStarships handles the requests to retrieve the starships of a
+// person.
+func (h *Handler) RetrievePersonStarships(c *gin.Context) {
+	retrieveLinkedResources(c, h, retrievePersonStarshipsHandlerName, swapi.RetrievePersonStarships)
 }
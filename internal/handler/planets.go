@@ -1,42 +1,43 @@
 package handler
 
 import (
-	"net/http"
-
-	"github.com/pegondo/starwars-service/internal/errors"
-	"github.com/pegondo/starwars-service/internal/logger"
-	"github.com/pegondo/starwars-service/internal/request"
 	"github.com/pegondo/starwars-service/internal/resources/swapi"
 
 	"github.com/gin-gonic/gin"
 )
 
-// retrievePlanetsHandlerName is the name of the retrieve planets handler.
-const retrievePlanetsHandlerName = "retrive planets"
+const (
+	// retrievePlanetsHandlerName is the name of the retrieve planets handler.
+	retrievePlanetsHandlerName = "retrieve planets"
+	// retrievePlanetByIdHandlerName is the name of the retrieve planet by id
+	// handler.
+	retrievePlanetByIdHandlerName = "retrieve planet by id"
+	// retrievePlanetResidentsHandlerName is the name of the retrieve planet
+	// residents handler.
+	retrievePlanetResidentsHandlerName = "retrieve planet residents"
+	// retrievePlanetFilmsHandlerName is the name of the retrieve planet films
+	// handler.
+	retrievePlanetFilmsHandlerName = "retrieve planet films"
+)
 
-func RetrievePlanets(c *gin.Context) {
-	l := logger.Logger(c)
-	l.Info().Msgf("received request to the %s endpoint", retrievePlanetsHandlerName)
+// RetrievePlanets handles the requests to retrieve the planets collection.
+func (h *Handler) RetrievePlanets(c *gin.Context) {
+	retrieveResources(c, h, retrievePlanetsHandlerName, swapi.RetrievePlanets)
+}
 
-	params, err := request.Params(c)
-	if err != nil {
-		l.Warn().Msgf("invalid request parameters :: %v", err)
-		c.AbortWithError(http.StatusBadRequest, err)
-		return
-	}
+// RetrievePlanetById handles the requests to retrieve a single planet by its
+// id.
+func (h *Handler) RetrievePlanetById(c *gin.Context) {
+	retrieveResource(c, h, retrievePlanetByIdHandlerName, swapi.RetrievePlanetById)
+}
 
-	planets, err := swapi.RetrievePlanets(params)
-	if err != nil {
-		// If there is an issue while requesting for the planets, return a 500.
-		l.Error().Msg(err.Error())
-		err = errors.New(errors.InternalServerErrorCode, errors.InternalServerErrorMsg)
-		c.AbortWithError(http.StatusInternalServerError, err)
-		return
-	}
+// RetrievePlanetResidents handles the requests to retrieve the residents of a
+// planet.
+func (h *Handler) RetrievePlanetResidents(c *gin.Context) {
+	retrieveLinkedResources(c, h, retrievePlanetResidentsHandlerName, swapi.RetrievePlanetResidents)
+}
 
-	statusCode := getStatusCode(planets)
-	c.JSON(statusCode, Response[swapi.Planet]{
-		Data:  planets.Results,
-		Count: planets.Count,
-	})
+// RetrievePlanetFilms handles the requests to retrieve the films of a planet.
+func (h *Handler) RetrievePlanetFilms(c *gin.Context) {
+	retrieveLinkedResources(c, h, retrievePlanetFilmsHandlerName, swapi.RetrievePlanetFilms)
 }
@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"context"
+	stdErrors "errors"
+	"net/http"
+	"strings"
+
+	"github.com/pegondo/starwars-service/internal/errors"
+	"github.com/pegondo/starwars-service/internal/logger"
+	"github.com/pegondo/starwars-service/internal/request"
+	"github.com/pegondo/starwars-service/internal/resources/swapi"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// clientClosedRequestStatus is the non-standard HTTP status code used when
+	// the client closes the connection before the response is ready.
+	clientClosedRequestStatus = 499
+	// SwapiMirrorHeader is the response header with the comma-separated base
+	// URLs of the SWAPI mirrors that served the request. It's omitted if the
+	// request was served without requesting SWAPI.
+	SwapiMirrorHeader = "X-Swapi-Mirror"
+)
+
+// retrieveFn is a function that retrieves a page of resources of type T from
+// SWAPI with the given request parameters.
+type retrieveFn[T swapi.Resource] func(ctx context.Context, params request.RequestParams) (swapi.SwapiResponse[T], error)
+
+// retrieveByIdFn is a function that retrieves the resource of type T with the
+// given id from SWAPI, expanding the given link fields.
+type retrieveByIdFn[T swapi.Resource] func(ctx context.Context, id int, expandFields []string) (T, error)
+
+// retrieveLinkedFn is a function that retrieves a page of the resources of type
+// T linked by the resource with the given id from SWAPI with the given request
+// parameters.
+type retrieveLinkedFn[T swapi.Resource] func(
+	ctx context.Context,
+	id int,
+	params request.RequestParams,
+) (swapi.SwapiResponse[T], error)
+
+// abortWithRetrieveError aborts the request with the response error matching
+// the given error, returned while retrieving resources from SWAPI.
+func abortWithRetrieveError(c *gin.Context, l zerolog.Logger, err error) {
+	switch {
+	case stdErrors.Is(err, swapi.ErrUpstreamTimeout), stdErrors.Is(err, context.DeadlineExceeded):
+		// If SWAPI doesn't respond in time, or the request deadline is
+		// exceeded, return a 504.
+		l.Error().Msg(err.Error())
+		err = errors.New(errors.UpstreamTimeoutErrorCode, errors.UpstreamTimeoutErrorMsg)
+		c.AbortWithError(http.StatusGatewayTimeout, err)
+	case stdErrors.Is(err, swapi.ErrUpstreamUnavailable):
+		// If SWAPI is failing and the circuit breaker is open, return a 503.
+		l.Warn().Msg(err.Error())
+		err = errors.New(errors.UpstreamUnavailableErrorCode, errors.UpstreamUnavailableErrorMsg)
+		c.AbortWithError(http.StatusServiceUnavailable, err)
+	case stdErrors.Is(err, swapi.ErrRateLimited):
+		// If SWAPI rejects the requests because of its rate limit, return a
+		// 429.
+		l.Warn().Msg(err.Error())
+		err = errors.New(errors.UpstreamRateLimitedErrorCode, errors.UpstreamRateLimitedErrorMsg)
+		c.AbortWithError(http.StatusTooManyRequests, err)
+	case stdErrors.Is(err, swapi.ErrUpstreamError):
+		// If SWAPI fails or its response is invalid, return a 502.
+		l.Error().Msg(err.Error())
+		err = errors.New(errors.UpstreamErrorCode, errors.UpstreamErrorMsg)
+		c.AbortWithError(http.StatusBadGateway, err)
+	case stdErrors.Is(err, context.Canceled):
+		// If the client is gone, there is no one to respond to.
+		l.Warn().Msgf("request canceled by the client :: %v", err)
+		c.AbortWithStatus(clientClosedRequestStatus)
+	default:
+		// If there is an issue while requesting for the resources, return a
+		// 500.
+		l.Error().Msg(err.Error())
+		err = errors.New(errors.InternalServerErrorCode, errors.InternalServerErrorMsg)
+		c.AbortWithError(http.StatusInternalServerError, err)
+	}
+}
+
+// setSwapiMirrorHeader sets the SwapiMirrorHeader of the response with the
+// SWAPI mirrors that served the requests performed with ctx.
+func setSwapiMirrorHeader(c *gin.Context, ctx context.Context) {
+	if baseUrls := swapi.ServedBy(ctx); len(baseUrls) > 0 {
+		c.Header(SwapiMirrorHeader, strings.Join(baseUrls, ", "))
+	}
+}
+
+// validateParams returns the parameters of the given request to a collection
+// of resources of type T, validated for T. If any of them is invalid,
+// validateParams aborts the request with a 400 and returns false.
+func validateParams[T swapi.Resource](c *gin.Context, l zerolog.Logger) (params request.RequestParams, ok bool) {
+	params, err := request.Params(c, swapi.IsFilterField[T])
+	if err != nil {
+		l.Warn().Msgf("invalid request parameters :: %v", err)
+		c.AbortWithError(http.StatusBadRequest, err)
+		return params, false
+	}
+	if err = swapi.ValidateExpand[T](params.Expand); err != nil {
+		l.Warn().Msgf("invalid expand fields %v :: %v", params.Expand, err)
+		err = errors.New(errors.InvalidExpandErrorCode, errors.InvalidExpandErrorMsg)
+		c.AbortWithError(http.StatusBadRequest, err)
+		return params, false
+	}
+	if err = swapi.ValidateFields[T](params.Fields); err != nil {
+		l.Warn().Msgf("invalid fields %v :: %v", params.Fields, err)
+		err = errors.New(errors.InvalidFieldsErrorCode, errors.InvalidFieldsErrorMsg)
+		c.AbortWithError(http.StatusBadRequest, err)
+		return params, false
+	}
+	if err = swapi.ValidateFilters[T](params.Filters); err != nil {
+		l.Warn().Msgf("invalid filters %v :: %v", params.Filters, err)
+		err = errors.New(errors.InvalidFilterErrorCode, errors.InvalidFilterErrorMsg)
+		c.AbortWithError(http.StatusBadRequest, err)
+		return params, false
+	}
+	if err = swapi.ValidateFilterExpression[T](params.FilterExpression); err != nil {
+		l.Warn().Msgf("invalid filter expression :: %v", err)
+		c.AbortWithError(http.StatusBadRequest, request.InvalidFilterExpressionError(err))
+		return params, false
+	}
+	for _, sortCriteria := range params.Sort {
+		if err = swapi.ValidateSortCriteria[T](sortCriteria); err != nil {
+			l.Warn().Msgf("invalid sort field %s :: %v", sortCriteria.Field, err)
+			err = errors.New(errors.InvalidSortCriteriaErrorCode, errors.InvalidSortCriteriaErrorMsg)
+			c.AbortWithError(http.StatusBadRequest, err)
+			return params, false
+		}
+	}
+	return params, true
+}
+
+// retrieveResources handles a request to retrieve a collection of resources of
+// type T from the backends of h. handlerName is the name of the handler used
+// in the logs and retrieve is the function used to request the resources to
+// SWAPI.
+func retrieveResources[T swapi.Resource](c *gin.Context, h *Handler, handlerName string, retrieve retrieveFn[T]) {
+	l := logger.Logger(c)
+	l.Info().Msgf("received request to the %s endpoint", handlerName)
+
+	params, ok := validateParams[T](c, l)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := h.requestContext(c)
+	defer cancel()
+	resources, err := retrieve(ctx, params)
+	setSwapiMirrorHeader(c, ctx)
+	if err != nil {
+		abortWithRetrieveError(c, l, err)
+		return
+	}
+
+	respondWithResources(c, l, resources, params.Fields)
+}
+
+// respondWithResources responds to the request with the given resources,
+// trimmed to the given fields if there are any.
+func respondWithResources[T swapi.Resource](
+	c *gin.Context,
+	l zerolog.Logger,
+	resources swapi.SwapiResponse[T],
+	fields []string,
+) {
+	statusCode := getStatusCode(resources)
+	data := swapi.RewriteUrls(resources.Results, publicBaseUrl)
+	if len(fields) == 0 {
+		c.JSON(statusCode, Response[T]{
+			Data:        data,
+			Count:       resources.Count,
+			Suggestions: resources.Suggestions,
+		})
+		return
+	}
+
+	projections, err := swapi.Project(data, fields)
+	if err != nil {
+		abortWithRetrieveError(c, l, err)
+		return
+	}
+	c.JSON(statusCode, ProjectedResponse{
+		Data:        projections,
+		Count:       resources.Count,
+		Suggestions: resources.Suggestions,
+	})
+}
+
+// retrieveResource handles a request to retrieve a single resource of type T
+// by its id from the backends of h. handlerName is the name of the handler
+// used in the logs and retrieve is the function used to request the resource
+// to SWAPI.
+func retrieveResource[T swapi.Resource](c *gin.Context, h *Handler, handlerName string, retrieve retrieveByIdFn[T]) {
+	l := logger.Logger(c)
+	l.Info().Msgf("received request to the %s endpoint", handlerName)
+
+	id, err := request.Id(c)
+	if err != nil {
+		l.Warn().Msgf("invalid resource id :: %v", err)
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	expandFields := request.Expand(c)
+	if err = swapi.ValidateExpand[T](expandFields); err != nil {
+		l.Warn().Msgf("invalid expand fields %v :: %v", expandFields, err)
+		err = errors.New(errors.InvalidExpandErrorCode, errors.InvalidExpandErrorMsg)
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	ctx, cancel := h.requestContext(c)
+	defer cancel()
+	resource, err := retrieve(ctx, id, expandFields)
+	setSwapiMirrorHeader(c, ctx)
+	if stdErrors.Is(err, swapi.ErrNotFound) {
+		l.Warn().Msgf("resource %d not found", id)
+		err = errors.New(errors.ResourceNotFoundErrorCode, errors.ResourceNotFoundErrorMsg)
+		c.AbortWithError(http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		abortWithRetrieveError(c, l, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, swapi.RewriteUrl(resource, publicBaseUrl))
+}
+
+// retrieveLinkedResources handles a request to retrieve the collection of
+// resources of type T linked by the resource with the id in the request path
+// from the backends of h. handlerName is the name of the handler used in the
+// logs and retrieve is the function used to request the resources to SWAPI.
+func retrieveLinkedResources[T swapi.Resource](c *gin.Context, h *Handler, handlerName string, retrieve retrieveLinkedFn[T]) {
+	l := logger.Logger(c)
+	l.Info().Msgf("received request to the %s endpoint", handlerName)
+
+	id, err := request.Id(c)
+	if err != nil {
+		l.Warn().Msgf("invalid resource id :: %v", err)
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	params, ok := validateParams[T](c, l)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := h.requestContext(c)
+	defer cancel()
+	resources, err := retrieve(ctx, id, params)
+	setSwapiMirrorHeader(c, ctx)
+	if stdErrors.Is(err, swapi.ErrNotFound) {
+		l.Warn().Msgf("resource %d not found", id)
+		err = errors.New(errors.ResourceNotFoundErrorCode, errors.ResourceNotFoundErrorMsg)
+		c.AbortWithError(http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		abortWithRetrieveError(c, l, err)
+		return
+	}
+
+	respondWithResources(c, l, resources, params.Fields)
+}
@@ -0,0 +1,490 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pegondo/starwars-service/internal/errors"
+	"github.com/pegondo/starwars-service/internal/resources/swapi"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// testBackends returns the backends of a small dataset served from memory, so
+// the tests don't request SWAPI.
+func testBackends() swapi.Backends {
+	return swapi.MemoryBackends(swapi.Dataset{
+		People: []swapi.Person{
+			{Name: "Luke Skywalker", SkinColor: "fair", Height: "172", Url: "https://swapi.dev/api/people/1/"},
+			{Name: "C-3PO", SkinColor: "gold", Height: "167", Url: "https://swapi.dev/api/people/2/"},
+			{Name: "R2-D2", SkinColor: "white, blue", Height: "unknown", Url: "https://swapi.dev/api/people/3/"},
+		},
+	})
+}
+
+// serve performs a GET request to the given target with a router that
+// serves the people endpoints from the test backends.
+func serve(t *testing.T, target string) *httptest.ResponseRecorder {
+	h := New(testBackends())
+	r := gin.New()
+	r.Use(errors.RecoveryMiddleware())
+	r.GET(PeopleEndpoint, h.RetrievePeople)
+	r.GET(PeopleByIdEndpoint, h.RetrievePersonById)
+
+	req, err := http.NewRequest("GET", target, nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRetrievePeople(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		target     string
+		statusCode int
+		names      []string
+	}{
+		{
+			name:       "all_people",
+			target:     "/people",
+			statusCode: http.StatusOK,
+			names:      []string{"Luke Skywalker", "C-3PO", "R2-D2"},
+		},
+		{
+			name:       "partial_page",
+			target:     "/people?page=2&pageSize=2",
+			statusCode: http.StatusPartialContent,
+			names:      []string{"R2-D2"},
+		},
+		{
+			name:       "unknown_params_ignored",
+			target:     "/people?_=1700000000&utm_source=newsletter&utm_medium[x]=email&climate=arid",
+			statusCode: http.StatusOK,
+			names:      []string{"Luke Skywalker", "C-3PO", "R2-D2"},
+		},
+		{
+			name:       "sorted_by_name",
+			target:     "/people?sortField=name",
+			statusCode: http.StatusOK,
+			names:      []string{"C-3PO", "Luke Skywalker", "R2-D2"},
+		},
+		{
+			name:       "sorted_by_numeric_field",
+			target:     "/people?sortField=height",
+			statusCode: http.StatusOK,
+			names:      []string{"C-3PO", "Luke Skywalker", "R2-D2"},
+		},
+		{
+			name:       "sorted_by_numeric_field_desc",
+			target:     "/people?sortField=height&sortOrder=desc",
+			statusCode: http.StatusOK,
+			names:      []string{"Luke Skywalker", "C-3PO", "R2-D2"},
+		},
+		{
+			name:       "sorted_by_many_keys",
+			target:     "/people?sort=gender,-name",
+			statusCode: http.StatusOK,
+			names:      []string{"R2-D2", "Luke Skywalker", "C-3PO"},
+		},
+		{
+			name:       "sorted_with_id_tiebreaker",
+			target:     "/people?sort=-gender",
+			statusCode: http.StatusOK,
+			names:      []string{"Luke Skywalker", "C-3PO", "R2-D2"},
+		},
+		{
+			name:       "search",
+			target:     "/people?search=sky",
+			statusCode: http.StatusOK,
+			names:      []string{"Luke Skywalker"},
+		},
+		{
+			name:       "filter",
+			target:     "/people?skin_color=gold&skin_color=blue",
+			statusCode: http.StatusOK,
+			names:      []string{"C-3PO", "R2-D2"},
+		},
+		{
+			name:       "full_text_search",
+			target:     "/people?q=" + url.QueryEscape("Blue white"),
+			statusCode: http.StatusOK,
+			names:      []string{"R2-D2"},
+		},
+		{
+			name:       "filter_expression",
+			target:     "/people?filter=" + url.QueryEscape(`skin_color eq "gold" or (height gt 170 and not skin_color in ("gold"))`),
+			statusCode: http.StatusOK,
+			names:      []string{"Luke Skywalker", "C-3PO"},
+		},
+		{
+			name:       "range_filter",
+			target:     "/people?height[gt]=170",
+			statusCode: http.StatusOK,
+			names:      []string{"Luke Skywalker"},
+		},
+		{
+			name:       "range_filter_including_unknown",
+			target:     "/people?height[gt]=170&unknown=include",
+			statusCode: http.StatusOK,
+			names:      []string{"Luke Skywalker", "R2-D2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serve(t, tc.target)
+			require.Equal(t, tc.statusCode, w.Code)
+
+			var resp Response[swapi.Person]
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			names := []string{}
+			for _, person := range resp.Data {
+				names = append(names, person.Name)
+			}
+			require.Equal(t, tc.names, names)
+		})
+	}
+}
+
+func TestRetrievePersonById(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		target     string
+		statusCode int
+		errorCode  string
+	}{
+		{
+			name:       "existing_person",
+			target:     "/people/2",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "missing_person",
+			target:     "/people/4",
+			statusCode: http.StatusNotFound,
+			errorCode:  errors.ResourceNotFoundErrorCode,
+		},
+		{
+			name:       "invalid_id",
+			target:     "/people/abc",
+			statusCode: http.StatusBadRequest,
+			errorCode:  errors.InvalidIdErrorCode,
+		},
+		{
+			name:       "invalid_expand",
+			target:     "/people/1?expand=residents",
+			statusCode: http.StatusBadRequest,
+			errorCode:  errors.InvalidExpandErrorCode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serve(t, tc.target)
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.errorCode == "" {
+				return
+			}
+			var respErr errors.ResponseError
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &respErr))
+			require.Equal(t, tc.errorCode, respErr.ErrorCode)
+		})
+	}
+}
+
+func TestRetrievePersonById_RewritesUrls(t *testing.T) {
+	testCases := []struct {
+		name          string
+		publicBaseUrl string
+		target        string
+		url           string
+	}{
+		{
+			name:          "public_base_url",
+			publicBaseUrl: "https://starwars.example.com/api",
+			target:        "http://localhost:8080/people/2",
+			url:           "https://starwars.example.com/api/people/2",
+		},
+		{
+			name:          "untrusted_host",
+			publicBaseUrl: "https://starwars.example.com/api",
+			target:        "http://evil.example.com/people/2",
+			url:           "https://starwars.example.com/api/people/2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			originalPublicBaseUrl := publicBaseUrl
+			publicBaseUrl = tc.publicBaseUrl
+			defer func() { publicBaseUrl = originalPublicBaseUrl }()
+
+			w := serve(t, tc.target)
+			require.Equal(t, http.StatusOK, w.Code)
+			var person swapi.Person
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &person))
+			require.Equal(t, tc.url, person.Url)
+		})
+	}
+}
+
+func TestRetrievePeople_InvalidFilter(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		target string
+	}{
+		{
+			name:   "range_filter_on_non_numeric_field",
+			target: "/people?skin_color[gt]=1",
+		},
+		{
+			name:   "non_numeric_range_value",
+			target: "/people?height[gt]=tall",
+		},
+		{
+			name:   "invalid_operator",
+			target: "/people?height[ne]=172",
+		},
+		{
+			name:   "invalid_unknown_policy",
+			target: "/people?height[gt]=170&unknown=maybe",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serve(t, tc.target)
+			require.Equal(t, http.StatusBadRequest, w.Code)
+			var respErr errors.ResponseError
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &respErr))
+			require.Equal(t, errors.InvalidFilterErrorCode, respErr.ErrorCode)
+		})
+	}
+}
+
+func TestRetrievePeople_InvalidSortField(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		target string
+	}{
+		{
+			name:   "field_of_another_resource",
+			target: "/people?sortField=population",
+		},
+		{
+			name:   "link_field",
+			target: "/people?sortField=films",
+		},
+		{
+			name:   "not_a_field_name",
+			target: "/people?sortField=<invalid-sort-field>",
+		},
+		{
+			name:   "invalid_sort_key",
+			target: "/people?sort=name,-films",
+		},
+		{
+			name:   "sort_and_sort_field",
+			target: "/people?sort=name&sortField=height",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serve(t, tc.target)
+			require.Equal(t, http.StatusBadRequest, w.Code)
+			var respErr errors.ResponseError
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &respErr))
+			require.Equal(t, errors.InvalidSortCriteriaErrorCode, respErr.ErrorCode)
+		})
+	}
+}
+
+func TestRetrievePeople_InvalidFilterExpression(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		filter  string
+		message string
+	}{
+		{
+			name:    "syntax_error",
+			filter:  `height gt 170 and (skin_color eq "gold"`,
+			message: `The filter expression is invalid: expected ")", found the end of the expression at position 40.`,
+		},
+		{
+			name:    "unknown_field",
+			filter:  `height gt 170 or climate eq "arid"`,
+			message: `The filter expression is invalid: unknown field "climate" at position 18.`,
+		},
+		{
+			name:    "type_error",
+			filter:  `height eq "tall"`,
+			message: "The filter expression is invalid: height is a numeric field and must be compared to a number at position 11.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serve(t, "/people?filter="+url.QueryEscape(tc.filter))
+			require.Equal(t, http.StatusBadRequest, w.Code)
+			var respErr errors.ResponseError
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &respErr))
+			require.Equal(t, errors.InvalidFilterErrorCode, respErr.ErrorCode)
+			require.Equal(t, tc.message, respErr.ErrorMessage)
+		})
+	}
+}
+
+func TestRetrievePeople_FuzzySearch(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		target      string
+		statusCode  int
+		names       []string
+		suggestions []string
+		errorCode   string
+	}{
+		{
+			name:       "exact_search",
+			target:     "/people?search=sky",
+			statusCode: http.StatusOK,
+			names:      []string{"Luke Skywalker"},
+		},
+		{
+			name:        "exact_search_without_results",
+			target:      "/people?search=skywaker",
+			statusCode:  http.StatusOK,
+			names:       []string{},
+			suggestions: []string{"Luke Skywalker"},
+		},
+		{
+			name:        "fuzzy_search",
+			target:      "/people?search=r2d2&fuzzy=true",
+			statusCode:  http.StatusOK,
+			names:       []string{"R2-D2"},
+			suggestions: []string{"R2-D2"},
+		},
+		{
+			name:       "invalid_fuzzy",
+			target:     "/people?search=r2d2&fuzzy=yes-please",
+			statusCode: http.StatusBadRequest,
+			errorCode:  errors.InvalidSearchErrorCode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serve(t, tc.target)
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.errorCode != "" {
+				var respErr errors.ResponseError
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &respErr))
+				require.Equal(t, tc.errorCode, respErr.ErrorCode)
+				return
+			}
+
+			var resp Response[swapi.Person]
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			names := []string{}
+			for _, person := range resp.Data {
+				names = append(names, person.Name)
+			}
+			require.Equal(t, tc.names, names)
+			require.Equal(t, tc.suggestions, resp.Suggestions)
+		})
+	}
+}
+
+func TestRetrievePeople_Fields(t *testing.T) {
+	t.Parallel()
+
+	w := serve(t, "/people?fields=name,url&pageSize=2")
+	require.Equal(t, http.StatusPartialContent, w.Code)
+
+	var resp struct {
+		Data  []map[string]any `json:"data"`
+		Count int              `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, 3, resp.Count)
+	require.Equal(t, []map[string]any{
+		{"name": "Luke Skywalker", "url": publicBaseUrl + "/people/1"},
+		{"name": "C-3PO", "url": publicBaseUrl + "/people/2"},
+	}, resp.Data)
+}
+
+func TestRetrievePeople_InvalidFields(t *testing.T) {
+	t.Parallel()
+
+	for _, fields := range []string{"name,climate", "residents", "SkinColor"} {
+		t.Run(fields, func(t *testing.T) {
+			w := serve(t, "/people?fields="+url.QueryEscape(fields))
+			require.Equal(t, http.StatusBadRequest, w.Code)
+			var respErr errors.ResponseError
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &respErr))
+			require.Equal(t, errors.InvalidFieldsErrorCode, respErr.ErrorCode)
+		})
+	}
+}
+
+// blockingBackend is a Backend whose requests only finish when their context
+// is done.
+type blockingBackend[T swapi.Resource] struct{}
+
+func (blockingBackend[T]) List(ctx context.Context, _ int) (swapi.SwapiResponse[T], error) {
+	<-ctx.Done()
+	return swapi.SwapiResponse[T]{}, ctx.Err()
+}
+
+func (blockingBackend[T]) Search(ctx context.Context, _ string, _ int) (swapi.SwapiResponse[T], error) {
+	<-ctx.Done()
+	return swapi.SwapiResponse[T]{}, ctx.Err()
+}
+
+func (blockingBackend[T]) Get(ctx context.Context, _ int) (resource T, err error) {
+	<-ctx.Done()
+	return resource, ctx.Err()
+}
+
+func TestRetrievePeople_DeadlineExceeded(t *testing.T) {
+	t.Parallel()
+
+	h := &Handler{
+		backends: swapi.Backends{People: blockingBackend[swapi.Person]{}},
+		deadline: 50 * time.Millisecond,
+	}
+	r := gin.New()
+	r.Use(errors.RecoveryMiddleware())
+	r.GET(PeopleEndpoint, h.RetrievePeople)
+
+	for _, target := range []string{"/people", "/people?sort=name&expand=homeworld"} {
+		req, err := http.NewRequest("GET", target, nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusGatewayTimeout, w.Code)
+		var respErr errors.ResponseError
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &respErr))
+		require.Equal(t, errors.UpstreamTimeoutErrorCode, respErr.ErrorCode)
+	}
+}
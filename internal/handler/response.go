@@ -12,6 +12,21 @@ type Response[T swapi.Resource] struct {
 	Data []T `json:"data"`
 	// Count is the number of elements in the
 	Count int `json:"count"`
+	// Suggestions are the names similar to the search when no name contains
+	// it, to suggest them to the user.
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// ProjectedResponse represents the response of a handler whose resources are
+// trimmed to the fields requested.
+type ProjectedResponse struct {
+	// Data is the resource data, with only the requested fields.
+	Data []swapi.Projection `json:"data"`
+	// Count is the number of elements in the collection.
+	Count int `json:"count"`
+	// Suggestions are the names similar to the search when no name contains
+	// it, to suggest them to the user.
+	Suggestions []string `json:"suggestions,omitempty"`
 }
 
 // getStatusCode returns the HTTP status code to return regarding the number of
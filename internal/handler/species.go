@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"github.com/pegondo/starwars-service/internal/resources/swapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// retrieveSpeciesHandlerName is the name of the retrieve species handler.
+	retrieveSpeciesHandlerName = "retrieve species"
+	// retrieveSpeciesByIdHandlerName is the name of the retrieve species by id
+	// handler.
+	retrieveSpeciesByIdHandlerName = "retrieve species by id"
+	// retrieveSpeciesPeopleHandlerName is the name of the retrieve species
+	// people handler.
+	retrieveSpeciesPeopleHandlerName = "retrieve species people"
+	// retrieveSpeciesFilmsHandlerName is the name of the retrieve species films
+	// handler.
+	retrieveSpeciesFilmsHandlerName = "retrieve species films"
+)
+
+// RetrieveSpecies handles the requests to retrieve the species collection.
+func (h *Handler) RetrieveSpecies(c *gin.Context) {
+	retrieveResources(c, h, retrieveSpeciesHandlerName, swapi.RetrieveSpecies)
+}
+
+// RetrieveSpeciesById handles the requests to retrieve a single species by its
+// id.
+func (h *Handler) RetrieveSpeciesById(c *gin.Context) {
+	retrieveResource(c, h, retrieveSpeciesByIdHandlerName, swapi.RetrieveSpeciesById)
+}
+
+// RetrieveSpeciesPeople handles the requests to retrieve the people of a
+// species.
+func (h *Handler) RetrieveSpeciesPeople(c *gin.Context) {
+	retrieveLinkedResources(c, h, retrieveSpeciesPeopleHandlerName, swapi.RetrieveSpeciesPeople)
+}
+
+// RetrieveSpeciesFilms handles the requests to retrieve the films of a species.
+func (h *Handler) RetrieveSpeciesFilms(c *gin.Context) {
+	retrieveLinkedResources(c, h, retrieveSpeciesFilmsHandlerName, swapi.RetrieveSpeciesFilms)
+}
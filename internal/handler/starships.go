@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"github.com/pegondo/starwars-service/internal/resources/swapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// retrieveStarshipsHandlerName is the name of the retrieve starships
+	// handler.
+	retrieveStarshipsHandlerName = "retrieve starships"
+	// retrieveStarshipByIdHandlerName is the name of the retrieve starship by
+	// id handler.
+	retrieveStarshipByIdHandlerName = "retrieve starship by id"
+	// retrieveStarshipPilotsHandlerName is the name of the retrieve starship
+	// pilots handler.
+	retrieveStarshipPilotsHandlerName = "retrieve starship pilots"
+	// retrieveStarshipFilmsHandlerName is the name of the retrieve starship
+	// films handler.
+	retrieveStarshipFilmsHandlerName = "retrieve starship films"
+)
+
+// RetrieveStarships handles the requests to retrieve the starships collection.
+func (h *Handler) RetrieveStarships(c *gin.Context) {
+	retrieveResources(c, h, retrieveStarshipsHandlerName, swapi.RetrieveStarships)
+}
+
+// RetrieveStarshipById handles the requests to retrieve a single starship by
+// its id.
+func (h *Handler) RetrieveStarshipById(c *gin.Context) {
+	retrieveResource(c, h, retrieveStarshipByIdHandlerName, swapi.RetrieveStarshipById)
+}
+
+// RetrieveStarshipPilots handles the requests to retrieve the pilots of a
+// starship.
+func (h *Handler) RetrieveStarshipPilots(c *gin.Context) {
+	retrieveLinkedResources(c, h, retrieveStarshipPilotsHandlerName, swapi.RetrieveStarshipPilots)
+}
+
+// RetrieveStarshipFilms handles the requests to retrieve the films of a
+// starship.
+func (h *Handler) RetrieveStarshipFilms(c *gin.Context) {
+	retrieveLinkedResources(c, h, retrieveStarshipFilmsHandlerName, swapi.RetrieveStarshipFilms)
+}
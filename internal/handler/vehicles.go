@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"github.com/pegondo/starwars-service/internal/resources/swapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// retrieveVehiclesHandlerName is the name of the retrieve vehicles handler.
+	retrieveVehiclesHandlerName = "retrieve vehicles"
+	// retrieveVehicleByIdHandlerName is the name of the retrieve vehicle by id
+	// handler.
+	retrieveVehicleByIdHandlerName = "retrieve vehicle by id"
+	// retrieveVehiclePilotsHandlerName is the name of the retrieve vehicle
+	// pilots handler.
+	retrieveVehiclePilotsHandlerName = "retrieve vehicle pilots"
+	// retrieveVehicleFilmsHandlerName is the name of the retrieve vehicle films
+	// handler.
+	retrieveVehicleFilmsHandlerName = "retrieve vehicle films"
+)
+
+// RetrieveVehicles handles the requests to retrieve the vehicles collection.
+func (h *Handler) RetrieveVehicles(c *gin.Context) {
+	retrieveResources(c, h, retrieveVehiclesHandlerName, swapi.RetrieveVehicles)
+}
+
+// RetrieveVehicleById handles the requests to retrieve a single vehicle by its
+// id.
+func (h *Handler) RetrieveVehicleById(c *gin.Context) {
+	retrieveResource(c, h, retrieveVehicleByIdHandlerName, swapi.RetrieveVehicleById)
+}
+
+// RetrieveVehiclePilots handles the requests to retrieve the pilots of a
+// vehicle.
+func (h *Handler) RetrieveVehiclePilots(c *gin.Context) {
+	retrieveLinkedResources(c, h, retrieveVehiclePilotsHandlerName, swapi.RetrieveVehiclePilots)
+}
+
+// RetrieveVehicleFilms handles the requests to retrieve the films of a vehicle.
+func (h *Handler) RetrieveVehicleFilms(c *gin.Context) {
+	retrieveLinkedResources(c, h, retrieveVehicleFilmsHandlerName, swapi.RetrieveVehicleFilms)
+}
@@ -1,6 +1,8 @@
 package logger
 
 import (
+	"context"
+
 	"github.com/pegondo/starwars-service/internal/request"
 
 	"github.com/gin-gonic/gin"
@@ -11,13 +13,18 @@ import (
 // loggerKey is the key to find the logger in the request context.
 var loggerKey = "logger"
 
+// contextKey is the key to find the logger in the context of the HTTP request.
+type contextKey struct{}
+
 // Middleware is a middleware that attaches a logger to the request
-// context.
+// context. The logger is also attached to the context of the HTTP request, so
+// it can be retrieved with FromContext down the call chain.
 func Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		reqId := request.RequestId(c)
 		logger := log.With().Str("reqId", reqId).Logger()
 		c.Set(loggerKey, logger)
+		c.Request = c.Request.WithContext(NewContext(c.Request.Context(), logger))
 
 		c.Next()
 	}
@@ -39,3 +46,18 @@ func Logger(c *gin.Context) (l zerolog.Logger) {
 	}
 	return l
 }
+
+// NewContext returns a copy of ctx with the given logger attached, so
+// FromContext returns it.
+func NewContext(ctx context.Context, l zerolog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger attached to the given context. If there is no
+// logger in the context, FromContext returns the default one.
+func FromContext(ctx context.Context) zerolog.Logger {
+	if l, ok := ctx.Value(contextKey{}).(zerolog.Logger); ok {
+		return l
+	}
+	return log.Logger
+}
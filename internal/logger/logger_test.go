@@ -1,6 +1,7 @@
 package logger_test
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -45,3 +46,39 @@ func TestLogger(t *testing.T) {
 	json.Unmarshal(lw.Body.Bytes(), &lwBodyStruct)
 	require.NotNil(t, lwBodyStruct.ReqId)
 }
+
+func TestFromContext(t *testing.T) {
+	lw := httptest.NewRecorder()
+	log.Logger = zerolog.New(lw)
+
+	handler := func(c *gin.Context) {
+		l := logger.FromContext(c.Request.Context())
+		l.Info().Msg("<msg>")
+		c.Status(http.StatusNoContent)
+	}
+	r := buildRouter(handler)
+
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Check that the logger printed the request id.
+	var lwBodyStruct struct {
+		ReqId *string `json:"reqId"`
+	}
+	json.Unmarshal(lw.Body.Bytes(), &lwBodyStruct)
+	require.NotNil(t, lwBodyStruct.ReqId)
+}
+
+func TestFromContext_NoLogger(t *testing.T) {
+	lw := httptest.NewRecorder()
+	log.Logger = zerolog.New(lw)
+
+	l := logger.FromContext(context.Background())
+	l.Info().Msg("<msg>")
+
+	// Check that the default logger was used.
+	require.NotEmpty(t, lw.Body.String())
+}
@@ -1,11 +1,18 @@
 package request
 
 import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pegondo/starwars-service/internal/errors"
+	"github.com/pegondo/starwars-service/internal/filterexpr"
+	"github.com/pegondo/starwars-service/internal/utils"
 )
 
 const (
@@ -24,13 +31,72 @@ const (
 	// defaultSearchValue is the default value for the search query parameter.
 	defaultSearchValue = ""
 
+	// fuzzyParamKey is the key to get the query parameter that makes the
+	// search typo-tolerant.
+	fuzzyParamKey = "fuzzy"
+
+	// queryParamKey is the key to get the full-text search query parameter.
+	queryParamKey = "q"
+
+	// sortParamKey is the request parameter for the list of sort keys.
+	sortParamKey = "sort"
+	// sortKeysSeparator is the separator of the keys in the sort query
+	// parameter.
+	sortKeysSeparator = ","
+	// descendingSortKeyPrefix is the prefix of the sort keys sorted in
+	// descending order, e.g. "-height".
+	descendingSortKeyPrefix = "-"
+	// ascendingSortKeyPrefix is the optional prefix of the sort keys sorted in
+	// ascending order, e.g. "+name".
+	ascendingSortKeyPrefix = "+"
+
 	// sortFieldParamKey is the request parameter for the sort field.
 	sortFieldParamKey = "sortField"
 	// sortFieldParamKey is the request parameter for the sort order.
 	sortOrderParamKey = "sortOrder"
+
+	// expandParamKey is the key to get the expand query parameter.
+	expandParamKey = "expand"
+	// expandSeparator is the separator of the fields in the expand query
+	// parameter.
+	expandSeparator = ","
+
+	// fieldsParamKey is the key to get the query parameter with the fields of
+	// the resources to respond with.
+	fieldsParamKey = "fields"
+	// fieldsSeparator is the separator of the fields in the fields query
+	// parameter.
+	fieldsSeparator = ","
+
+	// filterParamKey is the key to get the filter expression query parameter.
+	filterParamKey = "filter"
+
+	// unknownParamKey is the key to get the query parameter with the policy
+	// for the unknown values in the range filters.
+	unknownParamKey = "unknown"
+
+	// idParamKey is the key to get the resource id path parameter.
+	idParamKey = "id"
 )
 
-// SortField represents a valid sort field.
+// reservedParamKeys are the query parameters that aren't filters.
+var reservedParamKeys = map[string]bool{
+	pageParamKey:                       true,
+	strings.ToLower(pageSizeParamKey):  true,
+	searchParamKey:                     true,
+	fuzzyParamKey:                      true,
+	queryParamKey:                      true,
+	sortParamKey:                       true,
+	strings.ToLower(sortFieldParamKey): true,
+	strings.ToLower(sortOrderParamKey): true,
+	expandParamKey:                     true,
+	fieldsParamKey:                     true,
+	unknownParamKey:                    true,
+	filterParamKey:                     true,
+}
+
+// SortField represents a sort field. The fields each resource can be sorted by
+// are validated with the resource.
 type SortField string
 
 const (
@@ -38,8 +104,21 @@ const (
 	NameSortField SortField = "name"
 	// CreatedSortField represents a sorting by creation date.
 	CreatedSortField SortField = "created"
+	// EditedSortField represents a sorting by edition date.
+	EditedSortField SortField = "edited"
 )
 
+// isFieldName returns whether s is a lowercased field name, made of letters,
+// digits and underscores, as SWAPI names its fields.
+func isFieldName(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
 // SortOrder represents a valid sort order.
 type SortOrder string
 
@@ -58,14 +137,10 @@ type SortCriteria struct {
 	Order SortOrder
 }
 
-// Validate validates if sc has valid data.
+// Validate validates if sc has valid data. The field must be a field name, but
+// whether the resource has it isn't validated.
 func (sc *SortCriteria) Validate() error {
-	switch sc.Field {
-	case "":
-	case NameSortField:
-	case CreatedSortField:
-		// OK.
-	default:
+	if !isFieldName(string(sc.Field)) {
 		return errors.New(errors.InvalidSortCriteriaErrorCode, errors.InvalidSortCriteriaErrorMsg)
 	}
 	switch sc.Order {
@@ -98,16 +173,145 @@ func GetSortCriteria(c *gin.Context) *SortCriteria {
 	}
 }
 
+// GetSort returns the sort keys in the parameters of the given request context,
+// in the order the results must be sorted by. The keys are read from the sort
+// query parameter, e.g. sort=gender,-height,name, where the fields prefixed
+// with "-" are sorted in descending order, and from the sortField and
+// sortOrder query parameters otherwise. If the request doesn't contain
+// information about the sorting, GetSort returns nil. If both the sort and
+// sortField query parameters are set, a key is empty or a field is repeated,
+// GetSort returns an error.
+func GetSort(c *gin.Context) ([]SortCriteria, error) {
+	sort := strings.ToLower(strings.TrimSpace(c.Query(sortParamKey)))
+	if sort == "" {
+		if sortCriteria := GetSortCriteria(c); sortCriteria != nil {
+			return []SortCriteria{*sortCriteria}, nil
+		}
+		return nil, nil
+	}
+	if c.Query(sortFieldParamKey) != "" {
+		return nil, errors.New(errors.InvalidSortCriteriaErrorCode, errors.InvalidSortCriteriaErrorMsg)
+	}
+
+	var keys []SortCriteria
+	seen := map[SortField]bool{}
+	for _, key := range strings.Split(sort, sortKeysSeparator) {
+		key = strings.TrimSpace(key)
+		order := AscendingOrder
+		if field, found := strings.CutPrefix(key, descendingSortKeyPrefix); found {
+			key, order = field, DescendingOrder
+		} else {
+			key = strings.TrimPrefix(key, ascendingSortKeyPrefix)
+		}
+		field := SortField(key)
+		if field == "" || seen[field] {
+			return nil, errors.New(errors.InvalidSortCriteriaErrorCode, errors.InvalidSortCriteriaErrorMsg)
+		}
+		seen[field] = true
+		keys = append(keys, SortCriteria{Field: field, Order: order})
+	}
+	return keys, nil
+}
+
+// FilterOperator represents the comparison a filter performs.
+type FilterOperator string
+
+const (
+	// EqualOperator matches the fields equal to any of the filter values,
+	// ignoring the case. It's the operator of the filters without one, although
+	// it can be written as "eq" too.
+	EqualOperator FilterOperator = ""
+	// GreaterThanOperator matches the numeric fields greater than the filter
+	// value.
+	GreaterThanOperator FilterOperator = "gt"
+	// GreaterOrEqualOperator matches the numeric fields greater than or equal
+	// to the filter value.
+	GreaterOrEqualOperator FilterOperator = "gte"
+	// LessThanOperator matches the numeric fields less than the filter value.
+	LessThanOperator FilterOperator = "lt"
+	// LessOrEqualOperator matches the numeric fields less than or equal to
+	// the filter value.
+	LessOrEqualOperator FilterOperator = "lte"
+)
+
+// equalOperatorKey is the operator key of the equality filters, e.g.
+// gender[eq]=female.
+const equalOperatorKey = "eq"
+
+// rangeOperators are the valid range filter operators.
+var rangeOperators = []FilterOperator{
+	GreaterThanOperator,
+	GreaterOrEqualOperator,
+	LessThanOperator,
+	LessOrEqualOperator,
+}
+
+// IsRange returns whether the operator compares numbers.
+func (o FilterOperator) IsRange() bool {
+	return o != EqualOperator
+}
+
+// UnknownPolicy represents what the range filters do with the values that
+// aren't numbers, such as "unknown".
+type UnknownPolicy string
+
+const (
+	// ExcludeUnknown represents that the resources whose value isn't a number
+	// don't match the range filters.
+	ExcludeUnknown UnknownPolicy = "exclude"
+	// IncludeUnknown represents that the resources whose value isn't a number
+	// match the range filters.
+	IncludeUnknown UnknownPolicy = "include"
+)
+
+// Filter represents a condition on a field of the resources.
+type Filter struct {
+	// Field is the name of the field, as SWAPI names it, lowercased.
+	Field string
+	// Operator is the comparison the filter performs.
+	Operator FilterOperator
+	// Values are the lowercased values the field is compared to. The equality
+	// filters match if the field has any of them, and the range filters have
+	// a single numeric value.
+	Values []string
+}
+
 // RequestParams represents the parameters of the request.
 type RequestParams struct {
 	// Page is the number of the page requested.
 	Page int
 	// PageSize is the size of the page requested.
 	PageSize int
-	// Search is the search criteria requested.
+	// Search is the search criteria requested, as requested, so SWAPI gets it
+	// unchanged. It's case folded to match the names locally, see
+	// utils.FoldCase.
 	Search string
-	// SortCriteria is the sorting criteria requested.
-	SortCriteria *SortCriteria
+	// Fuzzy is whether the search matches the names similar to Search, such
+	// as the ones with typos, instead of only the names that contain it.
+	Fuzzy bool
+	// Query is the full-text search query requested, which is searched in all
+	// the text fields of the resources. It's "" if no full-text search was
+	// requested.
+	Query string
+	// Sort are the sort keys requested, in the order the results must be
+	// sorted by. It's nil if no sorting was requested.
+	Sort []SortCriteria
+	// Expand is the list of link fields to expand. It's nil if no field was
+	// requested to be expanded.
+	Expand []string
+	// Fields are the fields of the resources to respond with, so the rest of
+	// them are left out of the response. It's nil if all the fields were
+	// requested.
+	Fields []string
+	// Filters are the filters the resources must match, sorted by field and
+	// operator. It's nil if no filter was requested.
+	Filters []Filter
+	// IncludeUnknown is whether the resources whose value isn't a number, such
+	// as "unknown", match the range filters. By default, they don't.
+	IncludeUnknown bool
+	// FilterExpression is the filter expression the resources must match,
+	// besides the filters. It's nil if no filter expression was requested.
+	FilterExpression filterexpr.Expr
 }
 
 // getNumericParam returns the parameter with the given key from the context. If
@@ -118,7 +322,9 @@ func getNumericParam(c *gin.Context, key string, defaultValue int) (value int, e
 }
 
 // Params extracts the request parameters from the context and returns them.
-func Params(c *gin.Context) (params RequestParams, err error) {
+// The query parameters whose field satisfies isFilterField are the filters,
+// see Filters.
+func Params(c *gin.Context, isFilterField func(field string) bool) (params RequestParams, err error) {
 	params = RequestParams{}
 
 	params.Page, err = getNumericParam(c, pageParamKey, defaultPageParam)
@@ -131,16 +337,211 @@ func Params(c *gin.Context) (params RequestParams, err error) {
 		return params, errors.New(errors.InvalidPageSizeErrorCode, errors.InvalidPageSizeErrorMsg)
 	}
 
-	search := c.DefaultQuery(searchParamKey, defaultSearchValue)
-	search = strings.ToLower(search)
-	params.Search = search
+	params.Search = c.DefaultQuery(searchParamKey, defaultSearchValue)
+
+	params.Fuzzy, err = strconv.ParseBool(c.DefaultQuery(fuzzyParamKey, "false"))
+	if err != nil {
+		return params, errors.New(errors.InvalidSearchErrorCode, errors.InvalidSearchErrorMsg)
+	}
+
+	params.Query = strings.TrimSpace(c.Query(queryParamKey))
 
-	params.SortCriteria = GetSortCriteria(c)
-	if params.SortCriteria != nil {
-		if err = params.SortCriteria.Validate(); err != nil {
+	params.Sort, err = GetSort(c)
+	if err != nil {
+		return params, err
+	}
+	for _, sortCriteria := range params.Sort {
+		if err = sortCriteria.Validate(); err != nil {
 			return params, err
 		}
 	}
 
+	params.Expand = Expand(c)
+	params.Fields = Fields(c)
+
+	params.Filters, err = Filters(c, isFilterField)
+	if err != nil {
+		return params, err
+	}
+	params.FilterExpression, err = FilterExpression(c)
+	if err != nil {
+		return params, err
+	}
+	// The unknown policy only applies to the range filters, so it's ignored
+	// without them.
+	if hasRangeFilter(params.Filters) {
+		switch UnknownPolicy(strings.ToLower(c.DefaultQuery(unknownParamKey, string(ExcludeUnknown)))) {
+		case ExcludeUnknown:
+		case IncludeUnknown:
+			params.IncludeUnknown = true
+		default:
+			return params, errors.New(errors.InvalidFilterErrorCode, errors.InvalidFilterErrorMsg)
+		}
+	}
+
 	return params, nil
 }
+
+// hasRangeFilter returns whether any of the given filters is a range filter.
+func hasRangeFilter(filters []Filter) bool {
+	for _, filter := range filters {
+		if filter.Operator.IsRange() {
+			return true
+		}
+	}
+	return false
+}
+
+// Filters returns the filters in the query parameters of the given request
+// context, which are the parameters whose lowercased field satisfies
+// isFilterField, but the pagination, search, sorting, expansion, fields,
+// filter expression and unknown policy ones. The rest of the parameters, such
+// as cache busters or tracking parameters, are ignored. The filters can be
+// equality filters, e.g. gender=female, or range filters, e.g.
+// population[gte]=1000000. The fields are lowercased and the values case
+// folded, and the values of the same equality filter, such as
+// gender=female&gender=male, are grouped in the same filter. If the operator of
+// a filter isn't valid or the value of a range filter isn't a number, Filters
+// returns an error. If there are no filters, Filters returns nil.
+func Filters(c *gin.Context, isFilterField func(field string) bool) ([]Filter, error) {
+	equalValues := map[string][]string{}
+	var filters []Filter
+	query := c.Request.URL.Query()
+	for _, key := range slices.Sorted(maps.Keys(query)) {
+		keyValues := query[key]
+		field, operator, err := parseFilterKey(key)
+		if field == "" || reservedParamKeys[field] || !isFilterField(field) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range keyValues {
+			value = utils.FoldCase(strings.TrimSpace(value))
+			if value == "" {
+				continue
+			}
+			if !operator.IsRange() {
+				if !slices.Contains(equalValues[field], value) {
+					equalValues[field] = append(equalValues[field], value)
+				}
+				continue
+			}
+			if number, err := strconv.ParseFloat(value, 64); err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+				return nil, errors.New(errors.InvalidFilterErrorCode, errors.InvalidFilterErrorMsg)
+			}
+			filters = append(filters, Filter{
+				Field:    field,
+				Operator: operator,
+				Values:   []string{value},
+			})
+		}
+	}
+
+	for field, values := range equalValues {
+		filters = append(filters, Filter{
+			Field:    field,
+			Operator: EqualOperator,
+			Values:   values,
+		})
+	}
+	slices.SortFunc(filters, func(a, b Filter) int {
+		return cmp.Or(
+			cmp.Compare(a.Field, b.Field),
+			cmp.Compare(a.Operator, b.Operator),
+			slices.Compare(a.Values, b.Values),
+		)
+	})
+	return filters, nil
+}
+
+// FilterExpression returns the parsed filter expression in the filter query
+// parameter of the given request context, e.g.
+// filter=gender eq "female" and height gt 170. If there's no filter
+// expression, FilterExpression returns nil. If the expression isn't valid,
+// FilterExpression returns an error whose message has the position of the
+// offending token.
+func FilterExpression(c *gin.Context) (filterexpr.Expr, error) {
+	filter := c.Query(filterParamKey)
+	if strings.TrimSpace(filter) == "" {
+		return nil, nil
+	}
+	expr, err := filterexpr.Parse(filter)
+	if err != nil {
+		return nil, InvalidFilterExpressionError(err)
+	}
+	return expr, nil
+}
+
+// InvalidFilterExpressionError returns the response error for the given error
+// of a filter expression, which describes the problem and its position.
+func InvalidFilterExpressionError(err error) error {
+	return errors.New(errors.InvalidFilterErrorCode, fmt.Sprintf("%s: %v.", errors.InvalidFilterExpressionErrorMsg, err))
+}
+
+// parseFilterKey returns the lowercased field and the operator of the filter
+// with the given query parameter key, e.g. "population" and
+// GreaterOrEqualOperator for "population[gte]". The keys without an operator
+// are equality filters. If the operator isn't valid, parseFilterKey returns the
+// field and an error.
+func parseFilterKey(key string) (field string, operator FilterOperator, err error) {
+	key = strings.ToLower(strings.TrimSpace(key))
+	field, rest, found := strings.Cut(key, "[")
+	if !found {
+		return key, EqualOperator, nil
+	}
+	operatorKey, found := strings.CutSuffix(rest, "]")
+	switch {
+	case !found:
+		return field, "", errors.New(errors.InvalidFilterErrorCode, errors.InvalidFilterErrorMsg)
+	case operatorKey == equalOperatorKey:
+		return field, EqualOperator, nil
+	case !slices.Contains(rangeOperators, FilterOperator(operatorKey)):
+		return field, "", errors.New(errors.InvalidFilterErrorCode, errors.InvalidFilterErrorMsg)
+	}
+	return field, FilterOperator(operatorKey), nil
+}
+
+// Expand returns the link fields to expand in the expand query parameter of
+// the given request context, lowercased and without duplicates. If there are
+// no fields to expand, Expand returns nil.
+func Expand(c *gin.Context) []string {
+	var fields []string
+	seen := map[string]bool{}
+	for _, field := range strings.Split(c.Query(expandParamKey), expandSeparator) {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" || seen[field] {
+			continue
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// Fields returns the fields of the resources to respond with in the fields
+// query parameter of the given request context, lowercased and without
+// duplicates. If no fields were requested, Fields returns nil.
+func Fields(c *gin.Context) []string {
+	var fields []string
+	seen := map[string]bool{}
+	for _, field := range strings.Split(c.Query(fieldsParamKey), fieldsSeparator) {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" || seen[field] {
+			continue
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// Id extracts the resource id from the path parameters of the given request
+// context and returns it.
+func Id(c *gin.Context) (id int, err error) {
+	id, err = strconv.Atoi(c.Param(idParamKey))
+	if err != nil || id < 1 {
+		return id, errors.New(errors.InvalidIdErrorCode, errors.InvalidIdErrorMsg)
+	}
+	return id, nil
+}
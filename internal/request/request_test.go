@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"slices"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pegondo/starwars-service/internal/errors"
+	"github.com/pegondo/starwars-service/internal/filterexpr"
 	"github.com/pegondo/starwars-service/internal/request"
 	"github.com/stretchr/testify/require"
 )
@@ -18,6 +21,12 @@ func buildRouter(handler gin.HandlerFunc) *gin.Engine {
 	return r
 }
 
+// isTestFilterField returns whether the given field is one of the fields the
+// tests filter by.
+func isTestFilterField(field string) bool {
+	return slices.Contains([]string{"climate", "diameter", "eye_color", "gender", "height", "mass", "population"}, field)
+}
+
 func TestGetSortCriteria(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -212,6 +221,14 @@ func TestValidateSortCriteria(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "resource_sort_field",
+			sortCriteria: &request.SortCriteria{
+				Field: "birth_year",
+				Order: request.DescendingOrder,
+			},
+			err: nil,
+		},
 		{
 			name: "invalid_sort_field",
 			sortCriteria: &request.SortCriteria{
@@ -246,6 +263,84 @@ func TestValidateSortCriteria(t *testing.T) {
 	}
 }
 
+func TestGetSort(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+		sort  []request.SortCriteria
+		err   error
+	}{
+		{
+			name:  "no_sort",
+			query: "",
+			sort:  nil,
+		},
+		{
+			name:  "many_keys",
+			query: "sort=gender,-height,name",
+			sort: []request.SortCriteria{
+				{Field: "gender", Order: request.AscendingOrder},
+				{Field: "height", Order: request.DescendingOrder},
+				{Field: request.NameSortField, Order: request.AscendingOrder},
+			},
+		},
+		{
+			name:  "ascending_prefix_capitalized_and_spaced_keys",
+			query: "sort=" + url.QueryEscape(" +Name , -Created "),
+			sort: []request.SortCriteria{
+				{Field: request.NameSortField, Order: request.AscendingOrder},
+				{Field: request.CreatedSortField, Order: request.DescendingOrder},
+			},
+		},
+		{
+			name:  "sort_field_and_sort_order",
+			query: "sortField=name&sortOrder=desc",
+			sort: []request.SortCriteria{
+				{Field: request.NameSortField, Order: request.DescendingOrder},
+			},
+		},
+		{
+			name:  "sort_and_sort_field",
+			query: "sort=name&sortField=created",
+			err:   errors.New(errors.InvalidSortCriteriaErrorCode, errors.InvalidSortCriteriaErrorMsg),
+		},
+		{
+			name:  "repeated_field",
+			query: "sort=name,-name",
+			err:   errors.New(errors.InvalidSortCriteriaErrorCode, errors.InvalidSortCriteriaErrorMsg),
+		},
+		{
+			name:  "empty_key",
+			query: "sort=name,,created",
+			err:   errors.New(errors.InvalidSortCriteriaErrorCode, errors.InvalidSortCriteriaErrorMsg),
+		},
+		{
+			name:  "prefix_without_field",
+			query: "sort=-",
+			err:   errors.New(errors.InvalidSortCriteriaErrorCode, errors.InvalidSortCriteriaErrorMsg),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var sort []request.SortCriteria
+			var err error
+			handler := func(c *gin.Context) {
+				sort, err = request.GetSort(c)
+			}
+			r := buildRouter(handler)
+
+			req, reqErr := http.NewRequest("GET", "/?"+tc.query, nil)
+			require.NoError(t, reqErr)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.sort, sort)
+		})
+	}
+}
+
 func TestGetParams(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -367,7 +462,7 @@ func TestGetParams(t *testing.T) {
 			params: request.RequestParams{
 				Page:     1,
 				PageSize: 1,
-				Search:   "<search>",
+				Search:   "<SeArCH>",
 			},
 			err: nil,
 		},
@@ -385,9 +480,11 @@ func TestGetParams(t *testing.T) {
 				Page:     1,
 				PageSize: 1,
 				Search:   "<search>",
-				SortCriteria: &request.SortCriteria{
-					Field: request.NameSortField,
-					Order: request.AscendingOrder,
+				Sort: []request.SortCriteria{
+					{
+						Field: request.NameSortField,
+						Order: request.AscendingOrder,
+					},
 				},
 			},
 			err: nil,
@@ -399,7 +496,7 @@ func TestGetParams(t *testing.T) {
 			var params request.RequestParams
 			var err error
 			handler := func(c *gin.Context) {
-				params, err = request.Params(c)
+				params, err = request.Params(c, isTestFilterField)
 			}
 			r := buildRouter(handler)
 
@@ -419,3 +516,540 @@ func TestGetParams(t *testing.T) {
 		})
 	}
 }
+
+func TestId(t *testing.T) {
+	testCases := []struct {
+		name string
+		id   string
+		want int
+		err  error
+	}{
+		{
+			name: "valid_id",
+			id:   "3",
+			want: 3,
+			err:  nil,
+		},
+		{
+			name: "zero_id",
+			id:   "0",
+			err:  errors.New(errors.InvalidIdErrorCode, errors.InvalidIdErrorMsg),
+		},
+		{
+			name: "negative_id",
+			id:   "-1",
+			err:  errors.New(errors.InvalidIdErrorCode, errors.InvalidIdErrorMsg),
+		},
+		{
+			name: "invalid_id",
+			id:   "<invalid-id>",
+			err:  errors.New(errors.InvalidIdErrorCode, errors.InvalidIdErrorMsg),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var id int
+			var err error
+			handler := func(c *gin.Context) {
+				id, err = request.Id(c)
+			}
+			r := gin.Default()
+			r.GET("/:id", handler)
+
+			req, reqErr := http.NewRequest("GET", "/"+tc.id, nil)
+			require.NoError(t, reqErr)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.err, err)
+			if tc.err == nil {
+				require.Equal(t, tc.want, id)
+			}
+		})
+	}
+}
+
+func TestExpand(t *testing.T) {
+	testCases := []struct {
+		name   string
+		expand string
+		fields []string
+	}{
+		{
+			name:   "empty_expand",
+			expand: "",
+			fields: nil,
+		},
+		{
+			name:   "single_field",
+			expand: "homeworld",
+			fields: []string{"homeworld"},
+		},
+		{
+			name:   "many_fields",
+			expand: "homeworld,films",
+			fields: []string{"homeworld", "films"},
+		},
+		{
+			name:   "capitalized_and_spaced_fields",
+			expand: " HomeWorld , Films ",
+			fields: []string{"homeworld", "films"},
+		},
+		{
+			name:   "duplicated_and_empty_fields",
+			expand: "films,,films",
+			fields: []string{"films"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var fields []string
+			handler := func(c *gin.Context) {
+				fields = request.Expand(c)
+			}
+			r := buildRouter(handler)
+
+			req, err := http.NewRequest("GET", "/?expand="+url.QueryEscape(tc.expand), nil)
+			require.NoError(t, err)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.fields, fields)
+		})
+	}
+}
+
+func TestFilters(t *testing.T) {
+	testCases := []struct {
+		name    string
+		query   string
+		filters []request.Filter
+		err     bool
+	}{
+		{
+			name:    "no_filters",
+			query:   "page=2&pageSize=5&search=sky&sortField=name&sortOrder=desc&expand=films",
+			filters: nil,
+		},
+		{
+			name:  "single_filter",
+			query: "gender=female",
+			filters: []request.Filter{
+				{Field: "gender", Values: []string{"female"}},
+			},
+		},
+		{
+			name:  "many_filters_sorted_by_field",
+			query: "page=1&gender=female&eye_color=blue",
+			filters: []request.Filter{
+				{Field: "eye_color", Values: []string{"blue"}},
+				{Field: "gender", Values: []string{"female"}},
+			},
+		},
+		{
+			name:  "many_values",
+			query: "gender=female&gender=male&gender=female",
+			filters: []request.Filter{
+				{Field: "gender", Values: []string{"female", "male"}},
+			},
+		},
+		{
+			name:  "capitalized_and_spaced_filters",
+			query: "Eye_Color=%20Blue%20",
+			filters: []request.Filter{
+				{Field: "eye_color", Values: []string{"blue"}},
+			},
+		},
+		{
+			name:    "empty_values",
+			query:   "gender=",
+			filters: nil,
+		},
+		{
+			name:  "equal_operator",
+			query: "gender[eq]=female&gender=male",
+			filters: []request.Filter{
+				{Field: "gender", Values: []string{"male", "female"}},
+			},
+		},
+		{
+			name:  "range_filters_sorted_by_field_and_operator",
+			query: "population[gte]=1000000&diameter[lt]=10000&population[lte]=2e9&climate=arid",
+			filters: []request.Filter{
+				{Field: "climate", Values: []string{"arid"}},
+				{Field: "diameter", Operator: request.LessThanOperator, Values: []string{"10000"}},
+				{Field: "population", Operator: request.GreaterOrEqualOperator, Values: []string{"1000000"}},
+				{Field: "population", Operator: request.LessOrEqualOperator, Values: []string{"2e9"}},
+			},
+		},
+		{
+			name:  "range_filter_many_values",
+			query: "height[gt]=100&height[gt]=150.5",
+			filters: []request.Filter{
+				{Field: "height", Operator: request.GreaterThanOperator, Values: []string{"100"}},
+				{Field: "height", Operator: request.GreaterThanOperator, Values: []string{"150.5"}},
+			},
+		},
+		{
+			name:  "capitalized_operator",
+			query: "Mass[GT]=80",
+			filters: []request.Filter{
+				{Field: "mass", Operator: request.GreaterThanOperator, Values: []string{"80"}},
+			},
+		},
+		{
+			name:  "unknown_params",
+			query: "_=1700000000&utm_source=newsletter&utm_medium[x]=email&gender=female",
+			filters: []request.Filter{
+				{Field: "gender", Values: []string{"female"}},
+			},
+		},
+		{
+			name:    "unknown_param_with_range_operator",
+			query:   "utm_campaign[gte]=spring",
+			filters: nil,
+		},
+		{
+			name:  "invalid_operator",
+			query: "height[ne]=100",
+			err:   true,
+		},
+		{
+			name:  "unclosed_operator",
+			query: "height[gt=100",
+			err:   true,
+		},
+		{
+			name:  "non_numeric_range_value",
+			query: "population[gte]=unknown",
+			err:   true,
+		},
+		{
+			name:  "infinite_range_value",
+			query: "population[gte]=inf",
+			err:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var filters []request.Filter
+			var filtersErr error
+			handler := func(c *gin.Context) {
+				filters, filtersErr = request.Filters(c, isTestFilterField)
+			}
+			r := buildRouter(handler)
+
+			req, err := http.NewRequest("GET", "/?"+tc.query, nil)
+			require.NoError(t, err)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if tc.err {
+				require.Equal(t, errors.New(errors.InvalidFilterErrorCode, errors.InvalidFilterErrorMsg), filtersErr)
+				return
+			}
+			require.NoError(t, filtersErr)
+			require.Equal(t, tc.filters, filters)
+		})
+	}
+}
+
+func TestGetParams_UnknownPolicy(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		includeUnknown bool
+		err            error
+	}{
+		{
+			name:           "default_policy",
+			query:          "population[gte]=1000",
+			includeUnknown: false,
+		},
+		{
+			name:           "exclude_unknown",
+			query:          "population[gte]=1000&unknown=exclude",
+			includeUnknown: false,
+		},
+		{
+			name:           "include_unknown",
+			query:          "population[gte]=1000&unknown=Include",
+			includeUnknown: true,
+		},
+		{
+			name:  "invalid_policy",
+			query: "population[gte]=1000&unknown=maybe",
+			err:   errors.New(errors.InvalidFilterErrorCode, errors.InvalidFilterErrorMsg),
+		},
+		{
+			// Without range filters, the policy isn't used, so it isn't
+			// validated.
+			name:           "invalid_policy_without_range_filters",
+			query:          "gender=female&unknown=maybe",
+			includeUnknown: false,
+		},
+		{
+			name:           "include_unknown_without_range_filters",
+			query:          "unknown=include",
+			includeUnknown: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var params request.RequestParams
+			var err error
+			handler := func(c *gin.Context) {
+				params, err = request.Params(c, isTestFilterField)
+			}
+			r := buildRouter(handler)
+
+			req, reqErr := http.NewRequest("GET", "/?"+tc.query, nil)
+			require.NoError(t, reqErr)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.err, err)
+			if tc.err == nil {
+				require.Equal(t, tc.includeUnknown, params.IncludeUnknown)
+			}
+		})
+	}
+}
+
+func TestFilterExpression(t *testing.T) {
+	testCases := []struct {
+		name   string
+		filter string
+		isNil  bool
+		err    error
+	}{
+		{
+			name:   "no_expression",
+			filter: "",
+			isNil:  true,
+		},
+		{
+			name:   "valid_expression",
+			filter: `gender eq "female" and (height gt 170 or eye_color in ("blue", "green"))`,
+		},
+		{
+			name:   "invalid_expression",
+			filter: `gender eq "female" and (height 170)`,
+			err: errors.New(errors.InvalidFilterErrorCode,
+				`The filter expression is invalid: expected an operator, found "170" at position 32.`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var expr filterexpr.Expr
+			var err error
+			handler := func(c *gin.Context) {
+				expr, err = request.FilterExpression(c)
+			}
+			r := buildRouter(handler)
+
+			req, reqErr := http.NewRequest("GET", "/?filter="+url.QueryEscape(tc.filter), nil)
+			require.NoError(t, reqErr)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.isNil || tc.err != nil, expr == nil)
+		})
+	}
+}
+
+func TestGetParams_Query(t *testing.T) {
+	testCases := []struct {
+		name  string
+		q     string
+		query string
+	}{
+		{
+			name:  "no_query",
+			q:     "",
+			query: "",
+		},
+		{
+			name:  "query",
+			q:     "desert planet",
+			query: "desert planet",
+		},
+		{
+			name:  "trimmed_query",
+			q:     "  Tatooine\t",
+			query: "Tatooine",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var params request.RequestParams
+			var err error
+			handler := func(c *gin.Context) {
+				params, err = request.Params(c, isTestFilterField)
+			}
+			r := buildRouter(handler)
+
+			req, reqErr := http.NewRequest("GET", "/?q="+url.QueryEscape(tc.q), nil)
+			require.NoError(t, reqErr)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.query, params.Query)
+			require.Nil(t, params.Filters)
+		})
+	}
+}
+
+func TestGetParams_Fuzzy(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+		fuzzy bool
+		err   error
+	}{
+		{
+			name:  "default",
+			query: "search=yoda",
+			fuzzy: false,
+		},
+		{
+			name:  "fuzzy",
+			query: "search=yoda&fuzzy=true",
+			fuzzy: true,
+		},
+		{
+			name:  "not_fuzzy",
+			query: "search=yoda&fuzzy=false",
+			fuzzy: false,
+		},
+		{
+			name:  "invalid",
+			query: "search=yoda&fuzzy=maybe",
+			err:   errors.New(errors.InvalidSearchErrorCode, errors.InvalidSearchErrorMsg),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var params request.RequestParams
+			var err error
+			handler := func(c *gin.Context) {
+				params, err = request.Params(c, isTestFilterField)
+			}
+			r := buildRouter(handler)
+
+			req, reqErr := http.NewRequest("GET", "/?"+tc.query, nil)
+			require.NoError(t, reqErr)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.err, err)
+			if tc.err == nil {
+				require.Equal(t, tc.fuzzy, params.Fuzzy)
+				require.Nil(t, params.Filters)
+			}
+		})
+	}
+}
+
+func TestGetParams_RawSearch(t *testing.T) {
+	testCases := []struct {
+		name   string
+		search string
+	}{
+		{
+			name:   "accented",
+			search: "PADMÉ",
+		},
+		{
+			name:   "decomposed_accent",
+			search: "Padme\u0301",
+		},
+		{
+			name:   "sharp_s",
+			search: "Straße",
+		},
+		{
+			name:   "url_characters",
+			search: "Luke&Page=9#Films",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var params request.RequestParams
+			var err error
+			handler := func(c *gin.Context) {
+				params, err = request.Params(c, isTestFilterField)
+			}
+			r := buildRouter(handler)
+
+			req, reqErr := http.NewRequest("GET", "/?search="+url.QueryEscape(tc.search), nil)
+			require.NoError(t, reqErr)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.NoError(t, err)
+			// The search is kept as requested, so SWAPI gets it unchanged.
+			require.Equal(t, tc.search, params.Search)
+			require.Nil(t, params.Filters)
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	testCases := []struct {
+		name        string
+		fieldsParam string
+		fields      []string
+	}{
+		{
+			name:        "empty_fields",
+			fieldsParam: "",
+			fields:      nil,
+		},
+		{
+			name:        "many_fields",
+			fieldsParam: "name,height,url",
+			fields:      []string{"name", "height", "url"},
+		},
+		{
+			name:        "capitalized_and_spaced_fields",
+			fieldsParam: " Name , URL ",
+			fields:      []string{"name", "url"},
+		},
+		{
+			name:        "duplicated_and_empty_fields",
+			fieldsParam: "name,,name",
+			fields:      []string{"name"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var params request.RequestParams
+			var err error
+			handler := func(c *gin.Context) {
+				params, err = request.Params(c, isTestFilterField)
+			}
+			r := buildRouter(handler)
+
+			req, reqErr := http.NewRequest("GET", "/?fields="+url.QueryEscape(tc.fieldsParam), nil)
+			require.NoError(t, reqErr)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.fields, params.Fields)
+			require.Nil(t, params.Filters)
+		})
+	}
+}
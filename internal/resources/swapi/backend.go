@@ -0,0 +1,109 @@
+package swapi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Backend is a source of resources of type T. The pages of the backends have
+// the SWAPI fixed page size, swapiPageSize, so every backend can be paginated,
+// sorted and expanded in the same way. The backends must be comparable, as the
+// concurrent crawls of the same backend are shared.
+type Backend[T Resource] interface {
+	// List returns the page with the given number of the resources. If the
+	// page doesn't exist, List returns an empty page.
+	List(ctx context.Context, pageNumber int) (SwapiResponse[T], error)
+	// Search returns the page with the given number of the resources whose
+	// name contains search, ignoring the case, or whose model does for the
+	// vehicles and the starships. If the page doesn't exist, Search returns an
+	// empty page.
+	Search(ctx context.Context, search string, pageNumber int) (SwapiResponse[T], error)
+	// Get returns the resource with the given id. If there is no resource with
+	// that id, Get returns ErrNotFound.
+	Get(ctx context.Context, id int) (T, error)
+}
+
+// Backends are the sources of every type of resource the API serves.
+type Backends struct {
+	People    Backend[Person]
+	Planets   Backend[Planet]
+	Films     Backend[Film]
+	Species   Backend[Species]
+	Vehicles  Backend[Vehicle]
+	Starships Backend[Starship]
+}
+
+// backendsKey is the key of the context value with the sources the resources
+// are retrieved from.
+type backendsKey struct{}
+
+// WithBackends returns a copy of ctx that makes the resources retrieved with
+// it come from the given backends.
+func WithBackends(ctx context.Context, b Backends) context.Context {
+	return context.WithValue(ctx, backendsKey{}, b)
+}
+
+// backendsFrom returns the sources the resources retrieved with ctx come from,
+// set with WithBackends. If there are none, the resources are requested to
+// the SWAPI HTTP API.
+func backendsFrom(ctx context.Context) Backends {
+	if b, ok := ctx.Value(backendsKey{}).(Backends); ok {
+		return b
+	}
+	return HttpBackends()
+}
+
+// backendFor returns the source of the resources of type T retrieved with ctx.
+func backendFor[T Resource](ctx context.Context) Backend[T] {
+	return backendOf[T](backendsFrom(ctx))
+}
+
+// backendOf returns the backend of the given ones that serves the resources of
+// type T.
+func backendOf[T Resource](b Backends) Backend[T] {
+	var resource T
+	var backend any
+	switch any(resource).(type) {
+	case Person:
+		backend = b.People
+	case Planet:
+		backend = b.Planets
+	case Film:
+		backend = b.Films
+	case Species:
+		backend = b.Species
+	case Vehicle:
+		backend = b.Vehicles
+	case Starship:
+		backend = b.Starships
+	}
+	return backend.(Backend[T])
+}
+
+// listPage returns the page with the given number of the given backend. If
+// search isn't "", only the resources whose name contains it are listed.
+func listPage[T Resource](ctx context.Context, backend Backend[T], pageNumber int, search string) (SwapiResponse[T], error) {
+	if search != "" {
+		return backend.Search(ctx, search, pageNumber)
+	}
+	return backend.List(ctx, pageNumber)
+}
+
+// resourceId returns the id of the resource with the given URL. For example,
+// the id of "https://swapi.dev/api/people/1/" is 1. If the URL isn't a resource
+// URL, resourceId returns an error.
+func resourceId(resourceUrl string) (int, error) {
+	u, err := url.Parse(resourceUrl)
+	if err != nil {
+		return 0, err
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	id, err := strconv.Atoi(segments[len(segments)-1])
+	if err != nil || id < 1 {
+		return 0, fmt.Errorf("invalid resource URL %s", resourceUrl)
+	}
+	return id, nil
+}
@@ -0,0 +1,49 @@
+package swapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceId(t *testing.T) {
+	testCases := []struct {
+		name        string
+		resourceUrl string
+		id          int
+		expectError bool
+	}{
+		{
+			name:        "people_url",
+			resourceUrl: "https://swapi.dev/api/people/1/",
+			id:          1,
+		},
+		{
+			name:        "url_without_trailing_slash",
+			resourceUrl: "https://swapi.dev/api/planets/12",
+			id:          12,
+		},
+		{
+			name:        "url_without_id",
+			resourceUrl: "https://swapi.dev/api/planets/",
+			expectError: true,
+		},
+		{
+			name:        "empty_url",
+			resourceUrl: "",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			id, err := resourceId(tc.resourceUrl)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.id, id)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package swapi
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CircuitState represents the state of the circuit breaker around SWAPI.
+type CircuitState string
+
+const (
+	// CircuitClosed is the state of the circuit breaker when SWAPI is healthy
+	// and the requests to it are performed.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen is the state of the circuit breaker when SWAPI is failing and
+	// the requests to it are rejected without being performed.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen is the state of the circuit breaker when it's probing
+	// whether SWAPI has recovered with a single request.
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// circuitBreaker stops requesting SWAPI when too many of the requests fail. It
+// opens when the ratio of failed requests in the current window reaches the
+// failure ratio, once there have been enough requests to trust it. After the
+// open timeout, it lets a single probe request through: if the probe succeeds,
+// it closes again, and if it fails, it stays open for another open timeout.
+type circuitBreaker struct {
+	mu sync.Mutex
+	// failureRatio is the ratio of failed requests that opens the breaker. If
+	// it isn't greater than zero, the breaker never opens.
+	failureRatio float64
+	// minRequests is the minimum number of requests in the window needed to
+	// open the breaker.
+	minRequests int
+	// window is the time the requests and failures are counted for.
+	window time.Duration
+	// openTimeout is the time the breaker stays open before probing SWAPI.
+	openTimeout time.Duration
+
+	state       CircuitState
+	requests    int
+	failures    int
+	windowStart time.Time
+	openedAt    time.Time
+	// probing reports whether the probe request of the half-open state is in
+	// flight.
+	probing bool
+	// now returns the current time. It's replaced in the tests.
+	now func() time.Time
+}
+
+// newCircuitBreaker creates and returns a closed circuit breaker with the given
+// configuration.
+func newCircuitBreaker(failureRatio float64, minRequests int, window, openTimeout time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		failureRatio: failureRatio,
+		minRequests:  minRequests,
+		window:       window,
+		openTimeout:  openTimeout,
+		state:        CircuitClosed,
+		now:          time.Now,
+	}
+}
+
+// State returns the current state of the breaker.
+func (b *circuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen && b.now().Sub(b.openedAt) >= b.openTimeout {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// allow reports whether a request can be performed. Every allowed request must
+// be reported with record once it finishes.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.setState(CircuitHalfOpen)
+		b.probing = true
+		return true
+	case CircuitHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record reports the result of a request allowed by the breaker.
+func (b *circuitBreaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	switch b.state {
+	case CircuitHalfOpen:
+		b.probing = false
+		if success {
+			b.resetWindow(now)
+			b.setState(CircuitClosed)
+		} else {
+			b.openedAt = now
+			b.setState(CircuitOpen)
+		}
+	case CircuitClosed:
+		if now.Sub(b.windowStart) >= b.window {
+			b.resetWindow(now)
+		}
+		b.requests++
+		if !success {
+			b.failures++
+		}
+		if b.failureRatio > 0 &&
+			b.requests >= b.minRequests &&
+			float64(b.failures) >= b.failureRatio*float64(b.requests) {
+			b.openedAt = now
+			b.setState(CircuitOpen)
+		}
+	}
+	// The results of the requests allowed before opening the breaker are
+	// ignored.
+}
+
+// resetWindow starts a new window to count the requests and failures at the
+// given time.
+func (b *circuitBreaker) resetWindow(now time.Time) {
+	b.requests = 0
+	b.failures = 0
+	b.windowStart = now
+}
+
+// setState changes the state of the breaker, logging the transition.
+func (b *circuitBreaker) setState(state CircuitState) {
+	if b.state == state {
+		return
+	}
+	log.Warn().Msgf("SWAPI circuit breaker changed from %s to %s", b.state, state)
+	b.state = state
+}
@@ -0,0 +1,99 @@
+package swapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := newCircuitBreaker(0.5, 4, time.Minute, 30*time.Second)
+	b.now = func() time.Time { return now }
+
+	// Not enough requests to open the breaker.
+	for range 3 {
+		require.True(t, b.allow())
+		b.record(false)
+	}
+	require.Equal(t, CircuitClosed, b.State())
+
+	// The failure ratio is reached with enough requests.
+	require.True(t, b.allow())
+	b.record(true)
+	require.Equal(t, CircuitOpen, b.State())
+	require.False(t, b.allow())
+
+	// After the open timeout, a single probe is allowed.
+	now = now.Add(30 * time.Second)
+	require.Equal(t, CircuitHalfOpen, b.State())
+	require.True(t, b.allow())
+	require.False(t, b.allow())
+
+	// A failed probe opens the breaker again.
+	b.record(false)
+	require.Equal(t, CircuitOpen, b.State())
+	require.False(t, b.allow())
+
+	// A successful probe closes the breaker.
+	now = now.Add(30 * time.Second)
+	require.True(t, b.allow())
+	b.record(true)
+	require.Equal(t, CircuitClosed, b.State())
+	require.True(t, b.allow())
+}
+
+func TestCircuitBreaker_Window(t *testing.T) {
+	now := time.Now()
+	b := newCircuitBreaker(0.5, 2, time.Minute, 30*time.Second)
+	b.now = func() time.Time { return now }
+
+	require.True(t, b.allow())
+	b.record(false)
+
+	// The failures of previous windows aren't counted.
+	now = now.Add(time.Minute)
+	require.True(t, b.allow())
+	b.record(false)
+	require.Equal(t, CircuitClosed, b.State())
+}
+
+func TestCircuitBreaker_Disabled(t *testing.T) {
+	b := newCircuitBreaker(0, 0, time.Minute, 30*time.Second)
+	for range 10 {
+		require.True(t, b.allow())
+		b.record(false)
+	}
+	require.Equal(t, CircuitClosed, b.State())
+}
+
+func TestFetch_BreakerOpen(t *testing.T) {
+	setRetryConfig(t, 1, time.Millisecond, 10*time.Millisecond)
+
+	var numRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		numRequests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	setUpstreams(t, &upstream{
+		baseUrl: server.URL,
+		breaker: newCircuitBreaker(0.5, 1, time.Minute, time.Minute),
+	})
+
+	_, err := fetch(context.Background(), server.URL+"/people/1/")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrUpstreamUnavailable)
+
+	// The breaker is open, so SWAPI isn't requested again.
+	_, err = fetch(context.Background(), server.URL+"/people/2/")
+	require.ErrorIs(t, err, ErrUpstreamUnavailable)
+	require.Equal(t, int32(1), numRequests.Load())
+	require.Equal(t, CircuitOpen, BreakerState())
+}
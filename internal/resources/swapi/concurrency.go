@@ -0,0 +1,57 @@
+package swapi
+
+import (
+	"context"
+	"sync"
+)
+
+// runConcurrently calls fn for every task index from 0 to numTasks-1, running
+// at most fetchConcurrency calls at the same time. It waits for all the calls
+// to finish and returns the first error returned by them, if any. The first
+// error cancels the context the calls run with, so the rest of them stop, as
+// in errgroup.WithContext. The tasks that haven't started when ctx is done are
+// skipped and runConcurrently returns the context error.
+func runConcurrently(ctx context.Context, numTasks int, fn func(ctx context.Context, i int) error) (err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	setErr := func(taskErr error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err == nil {
+			err = taskErr
+			cancel()
+		}
+	}
+
+	sem := make(chan struct{}, max(fetchConcurrency, 1))
+	for i := range numTasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				setErr(ctx.Err())
+				return
+			}
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				// A slot may be free once ctx is done, but the task must
+				// be skipped anyway.
+				setErr(ctx.Err())
+				return
+			}
+
+			if taskErr := fn(ctx, i); taskErr != nil {
+				setErr(taskErr)
+			}
+		}()
+	}
+	wg.Wait()
+	return err
+}
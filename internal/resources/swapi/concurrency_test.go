@@ -0,0 +1,74 @@
+package swapi
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunConcurrently(t *testing.T) {
+	originalConcurrency := fetchConcurrency
+	fetchConcurrency = 2
+	defer func() { fetchConcurrency = originalConcurrency }()
+
+	var running, maxRunning atomic.Int32
+	done := make([]bool, 10)
+	err := runConcurrently(context.Background(), len(done), func(_ context.Context, i int) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		done[i] = true
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.LessOrEqual(t, maxRunning.Load(), int32(2))
+	for _, d := range done {
+		require.True(t, d)
+	}
+}
+
+func TestRunConcurrently_Error(t *testing.T) {
+	expectedErr := errors.New("<error>")
+	err := runConcurrently(context.Background(), 5, func(_ context.Context, i int) error {
+		if i == 3 {
+			return expectedErr
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, expectedErr)
+}
+
+func TestRunConcurrently_ErrorCancelsTasks(t *testing.T) {
+	originalConcurrency := fetchConcurrency
+	fetchConcurrency = 2
+	defer func() { fetchConcurrency = originalConcurrency }()
+
+	expectedErr := errors.New("<error>")
+	var numCalls atomic.Int32
+	var runningErr error
+	err := runConcurrently(context.Background(), 10, func(ctx context.Context, _ int) error {
+		if numCalls.Add(1) == 1 {
+			// The first call is still running when the second one fails.
+			<-ctx.Done()
+			runningErr = ctx.Err()
+			return runningErr
+		}
+		return expectedErr
+	})
+
+	require.ErrorIs(t, err, expectedErr)
+	// The running call must be canceled and the rest of them skipped.
+	require.ErrorIs(t, runningErr, context.Canceled)
+	require.Equal(t, int32(2), numCalls.Load())
+}
@@ -0,0 +1,177 @@
+package swapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/pegondo/starwars-service/internal/cache"
+	"github.com/pegondo/starwars-service/internal/utils"
+)
+
+const (
+	// defaultSwapiBaseUrl is the SWAPI base URL used if SWAPI_BASE_URL isn't
+	// defined.
+	defaultSwapiBaseUrl = "https://swapi.dev/api"
+	// defaultCacheMaxSize is the maximum number of SWAPI responses cached if
+	// SWAPI_CACHE_MAX_SIZE isn't defined.
+	defaultCacheMaxSize = 1000
+	// defaultCacheTtl is the time SWAPI responses are cached for if
+	// SWAPI_CACHE_TTL isn't defined.
+	defaultCacheTtl = time.Hour
+	// defaultFetchConcurrency is the maximum number of concurrent requests to
+	// SWAPI per call if SWAPI_FETCH_CONCURRENCY isn't defined.
+	defaultFetchConcurrency = 5
+	// defaultRequestTimeout is the maximum time to wait for a SWAPI response if
+	// SWAPI_REQUEST_TIMEOUT isn't defined.
+	defaultRequestTimeout = 10 * time.Second
+	// defaultUpstreamDeadline is the maximum time to spend on each upstream
+	// call, i.e. on each URL requested to SWAPI, including its retries, if
+	// SWAPI_UPSTREAM_DEADLINE isn't defined.
+	defaultUpstreamDeadline = 30 * time.Second
+	// defaultRetryMaxAttempts is the maximum number of attempts to perform a
+	// request to SWAPI if SWAPI_RETRY_MAX_ATTEMPTS isn't defined.
+	defaultRetryMaxAttempts = 3
+	// defaultRetryBaseDelay is the time to wait before the first retry if
+	// SWAPI_RETRY_BASE_DELAY isn't defined.
+	defaultRetryBaseDelay = 100 * time.Millisecond
+	// defaultRetryMaxDelay is the maximum time to wait before a retry if
+	// SWAPI_RETRY_MAX_DELAY isn't defined.
+	defaultRetryMaxDelay = 2 * time.Second
+	// defaultBreakerFailureRatio is the ratio of failed requests to SWAPI that
+	// opens the circuit breaker if SWAPI_BREAKER_FAILURE_RATIO isn't defined.
+	defaultBreakerFailureRatio = 0.5
+	// defaultBreakerMinRequests is the minimum number of requests to SWAPI
+	// needed to open the circuit breaker if SWAPI_BREAKER_MIN_REQUESTS isn't
+	// defined.
+	defaultBreakerMinRequests = 10
+	// defaultBreakerWindow is the time the requests to SWAPI are counted for if
+	// SWAPI_BREAKER_WINDOW isn't defined.
+	defaultBreakerWindow = time.Minute
+	// defaultBreakerOpenTimeout is the time the circuit breaker stays open if
+	// SWAPI_BREAKER_OPEN_TIMEOUT isn't defined.
+	defaultBreakerOpenTimeout = 30 * time.Second
+	// defaultMaxBodySize is the maximum size in bytes of the SWAPI response
+	// bodies if SWAPI_MAX_BODY_SIZE isn't defined.
+	defaultMaxBodySize = 10 << 20
+	// defaultMirrorSyncInterval is how often the local mirror is synced with
+	// SWAPI if SWAPI_MIRROR_SYNC_INTERVAL isn't defined.
+	defaultMirrorSyncInterval = time.Hour
+	// defaultMirrorRetryInterval is how long to wait before retrying to
+	// populate an empty local mirror if SWAPI_MIRROR_RETRY_INTERVAL isn't
+	// defined.
+	defaultMirrorRetryInterval = 30 * time.Second
+	// defaultHealthCheckInterval is how often the health of the SWAPI mirrors
+	// is checked if SWAPI_HEALTH_CHECK_INTERVAL isn't defined.
+	defaultHealthCheckInterval = 30 * time.Second
+)
+
+var (
+	// swapiBaseUrls are the base URLs of the SWAPI mirrors, in order of
+	// preference.
+	swapiBaseUrls = parseBaseUrls(utils.EnvString("SWAPI_BASE_URL", defaultSwapiBaseUrl))
+	// swapiBaseUrl is the base URL of the preferred SWAPI mirror. The URLs
+	// requested to SWAPI are built with it and then sent to the mirror that
+	// serves them.
+	swapiBaseUrl = swapiBaseUrls[0]
+	// responseCache caches the body of the SWAPI responses by URL.
+	responseCache = cache.New[string, []byte](
+		utils.EnvInt("SWAPI_CACHE_MAX_SIZE", defaultCacheMaxSize),
+		utils.EnvDuration("SWAPI_CACHE_TTL", defaultCacheTtl),
+	)
+	// fetchConcurrency is the maximum number of requests to SWAPI performed at
+	// the same time to serve a single call, such as the pages of a collection
+	// or the resources to expand.
+	fetchConcurrency = utils.EnvInt("SWAPI_FETCH_CONCURRENCY", defaultFetchConcurrency)
+	// requestTimeout is the maximum time to wait for SWAPI to respond to each
+	// request.
+	requestTimeout = utils.EnvDuration("SWAPI_REQUEST_TIMEOUT", defaultRequestTimeout)
+	// upstreamDeadline is the maximum time to spend on each upstream call, i.e.
+	// on each URL requested to SWAPI, including all its attempts and the waits
+	// between them. The requests to the service that request many URLs are
+	// bounded by the request deadline of the handler.
+	upstreamDeadline = utils.EnvDuration("SWAPI_UPSTREAM_DEADLINE", defaultUpstreamDeadline)
+	// retryMaxAttempts is the maximum number of attempts to perform a request
+	// to SWAPI that fails with a transient error.
+	retryMaxAttempts = utils.EnvInt("SWAPI_RETRY_MAX_ATTEMPTS", defaultRetryMaxAttempts)
+	// retryBaseDelay is the time to wait before the first retry of a request
+	// to SWAPI. The following retries wait exponentially longer.
+	retryBaseDelay = utils.EnvDuration("SWAPI_RETRY_BASE_DELAY", defaultRetryBaseDelay)
+	// retryMaxDelay is the maximum time to wait before retrying a request to
+	// SWAPI. If SWAPI asks to wait longer with a Retry-After header, the
+	// request is retried after retryMaxDelay anyway.
+	retryMaxDelay = utils.EnvDuration("SWAPI_RETRY_MAX_DELAY", defaultRetryMaxDelay)
+	// maxBodySize is the maximum size in bytes of the SWAPI response bodies.
+	// The larger responses are rejected.
+	maxBodySize = int64(utils.EnvInt("SWAPI_MAX_BODY_SIZE", defaultMaxBodySize))
+	// datasetPath is the path to the JSON dataset to serve the resources from
+	// instead of requesting them to SWAPI. If it's "", SWAPI is requested.
+	datasetPath = utils.EnvString("SWAPI_DATASET", "")
+	// mirrorDir is the directory of the local mirror of SWAPI to serve the
+	// resources from. If it's "", there is no mirror.
+	mirrorDir = utils.EnvString("SWAPI_MIRROR_DIR", "")
+	// mirrorSyncInterval is how often the local mirror is synced with SWAPI.
+	mirrorSyncInterval = utils.EnvDuration("SWAPI_MIRROR_SYNC_INTERVAL", defaultMirrorSyncInterval)
+	// mirrorRetryInterval is how long to wait before retrying to populate an
+	// empty local mirror from SWAPI.
+	mirrorRetryInterval = utils.EnvDuration("SWAPI_MIRROR_RETRY_INTERVAL", defaultMirrorRetryInterval)
+	// breakerFailureRatio is the ratio of failed requests to a SWAPI mirror
+	// that opens its circuit breaker.
+	breakerFailureRatio = utils.EnvFloat("SWAPI_BREAKER_FAILURE_RATIO", defaultBreakerFailureRatio)
+	// breakerMinRequests is the minimum number of requests to a SWAPI mirror
+	// in the window needed to open its circuit breaker.
+	breakerMinRequests = utils.EnvInt("SWAPI_BREAKER_MIN_REQUESTS", defaultBreakerMinRequests)
+	// breakerWindow is the time the requests to a SWAPI mirror are counted for
+	// to compute its failure ratio.
+	breakerWindow = utils.EnvDuration("SWAPI_BREAKER_WINDOW", defaultBreakerWindow)
+	// breakerOpenTimeout is the time a circuit breaker stays open before
+	// letting a request through.
+	breakerOpenTimeout = utils.EnvDuration("SWAPI_BREAKER_OPEN_TIMEOUT", defaultBreakerOpenTimeout)
+	// upstreams are the SWAPI mirrors the resources are requested to.
+	upstreams = newUpstreams(swapiBaseUrls)
+	// healthCheckInterval is how often the health of the SWAPI mirrors is
+	// checked.
+	healthCheckInterval = utils.EnvDuration("SWAPI_HEALTH_CHECK_INTERVAL", defaultHealthCheckInterval)
+)
+
+// Init sets up and returns the backends the resources are retrieved from: the
+// JSON dataset in SWAPI_DATASET if it's defined, the local mirror in
+// SWAPI_MIRROR_DIR if it's defined, or the SWAPI HTTP API otherwise. Unless
+// the resources are served from a dataset, Init starts checking the health of
+// the SWAPI mirrors in the background.
+func Init() (Backends, error) {
+	if datasetPath == "" {
+		if healthCheckInterval > 0 {
+			go runHealthChecks(context.Background(), healthCheckInterval)
+		}
+		if mirrorDir != "" {
+			return initMirror(context.Background(), mirrorDir, HttpBackends(), mirrorSyncInterval)
+		}
+		return HttpBackends(), nil
+	}
+	dataset, err := LoadDataset(datasetPath)
+	if err != nil {
+		return Backends{}, err
+	}
+	return MemoryBackends(dataset), nil
+}
+
+// CacheStats returns the usage statistics of the SWAPI responses cache.
+func CacheStats() cache.Stats {
+	return responseCache.Stats()
+}
+
+// BreakerState returns the state of the circuit breakers around the SWAPI
+// mirrors as a whole: closed if any of them is closed, half-open if any of
+// them is half-open, or open if all of them are open.
+func BreakerState() CircuitState {
+	state := CircuitOpen
+	for _, u := range upstreams {
+		switch u.breaker.State() {
+		case CircuitClosed:
+			return CircuitClosed
+		case CircuitHalfOpen:
+			state = CircuitHalfOpen
+		}
+	}
+	return state
+}
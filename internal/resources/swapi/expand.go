@@ -0,0 +1,183 @@
+package swapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Link fields, named as SWAPI names them in its resources.
+const (
+	homeworldLink  = "homeworld"
+	filmsLink      = "films"
+	speciesLink    = "species"
+	vehiclesLink   = "vehicles"
+	starshipsLink  = "starships"
+	residentsLink  = "residents"
+	charactersLink = "characters"
+	planetsLink    = "planets"
+	peopleLink     = "people"
+	pilotsLink     = "pilots"
+)
+
+// singleLinks are the link fields that hold a single URL instead of a list of
+// URLs.
+var singleLinks = map[string]bool{
+	homeworldLink: true,
+}
+
+// links maps the link fields of a resource to the URLs of the resources they
+// link to.
+type links map[string][]string
+
+// Expansion maps the link fields of a resource to the resources they link to.
+// Single link fields, such as homeworld, hold a single resource, and the rest
+// of them hold a list of resources.
+type Expansion map[string]any
+
+// expandable is implemented by the resources that can hold the resources they
+// link to.
+type expandable interface {
+	setExpanded(expanded Expansion)
+}
+
+// optionalLink returns the links of a single link field that may be nil.
+func optionalLink(url *string) []string {
+	if url == nil {
+		return nil
+	}
+	return []string{*url}
+}
+
+// ValidateExpand validates that all the given fields are link fields of the
+// resource T. If any of them isn't, ValidateExpand returns
+// ErrInvalidExpandField.
+func ValidateExpand[T Resource](fields []string) error {
+	var resource T
+	links := resource.links()
+	for _, field := range fields {
+		if _, ok := links[field]; !ok {
+			return ErrInvalidExpandField
+		}
+	}
+	return nil
+}
+
+// resourceEndpoint returns the SWAPI endpoint of the given resource URL. For
+// example, the endpoint of "https://swapi.dev/api/people/1/" is "people". If
+// the URL isn't a resource URL, resourceEndpoint returns "".
+func resourceEndpoint(resourceUrl string) string {
+	u, err := url.Parse(resourceUrl)
+	if err != nil {
+		return ""
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 2 {
+		return ""
+	}
+	return segments[len(segments)-2]
+}
+
+// requestLinked requests the resource with the given URL and returns it as the
+// resource type of its endpoint.
+func requestLinked(ctx context.Context, resourceUrl string) (resource any, err error) {
+	id, err := resourceId(resourceUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	b := backendsFrom(ctx)
+	switch resourceEndpoint(resourceUrl) {
+	case peopleEndpoint:
+		return b.People.Get(ctx, id)
+	case planetsEndpoint:
+		return b.Planets.Get(ctx, id)
+	case filmsEndpoint:
+		return b.Films.Get(ctx, id)
+	case speciesEndpoint:
+		return b.Species.Get(ctx, id)
+	case vehiclesEndpoint:
+		return b.Vehicles.Get(ctx, id)
+	case starshipsEndpoint:
+		return b.Starships.Get(ctx, id)
+	default:
+		return nil, fmt.Errorf("unknown resource URL %s", resourceUrl)
+	}
+}
+
+// requestAllLinked requests the resources with the given URLs concurrently and
+// returns them indexed by their URL. The resources SWAPI doesn't find are left
+// out.
+func requestAllLinked(ctx context.Context, urls []string) (resources map[string]any, err error) {
+	var mu sync.Mutex
+	resources = make(map[string]any, len(urls))
+	err = runConcurrently(ctx, len(urls), func(ctx context.Context, i int) error {
+		resource, err := requestLinked(ctx, urls[i])
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		resources[urls[i]] = resource
+		return nil
+	})
+	return resources, err
+}
+
+// expand requests the resources linked in the given link fields of the given
+// resources and stores them in the resources' Expanded field. The linked
+// resources are requested only once, even if many resources link to them.
+func expand[T Resource](ctx context.Context, resources []T, fields []string) error {
+	if len(fields) == 0 || len(resources) == 0 {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	urls := []string{}
+	for _, resource := range resources {
+		links := resource.links()
+		for _, field := range fields {
+			for _, resourceUrl := range links[field] {
+				if resourceUrl != "" && !seen[resourceUrl] {
+					seen[resourceUrl] = true
+					urls = append(urls, resourceUrl)
+				}
+			}
+		}
+	}
+
+	linked, err := requestAllLinked(ctx, urls)
+	if err != nil {
+		return err
+	}
+
+	for i := range resources {
+		links := resources[i].links()
+		expanded := make(Expansion, len(fields))
+		for _, field := range fields {
+			if singleLinks[field] {
+				var resource any
+				if urls := links[field]; len(urls) > 0 {
+					resource = linked[urls[0]]
+				}
+				expanded[field] = resource
+				continue
+			}
+			list := make([]any, 0, len(links[field]))
+			for _, resourceUrl := range links[field] {
+				if resource, ok := linked[resourceUrl]; ok {
+					list = append(list, resource)
+				}
+			}
+			expanded[field] = list
+		}
+		any(&resources[i]).(expandable).setExpanded(expanded)
+	}
+	return nil
+}
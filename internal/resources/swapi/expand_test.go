@@ -0,0 +1,147 @@
+package swapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceEndpoint(t *testing.T) {
+	testCases := []struct {
+		name        string
+		resourceUrl string
+		endpoint    string
+	}{
+		{
+			name:        "people_url",
+			resourceUrl: "https://swapi.dev/api/people/1/",
+			endpoint:    peopleEndpoint,
+		},
+		{
+			name:        "url_without_trailing_slash",
+			resourceUrl: "https://swapi.dev/api/planets/1",
+			endpoint:    planetsEndpoint,
+		},
+		{
+			name:        "url_without_id",
+			resourceUrl: "https://swapi.dev",
+			endpoint:    "",
+		},
+		{
+			name:        "empty_url",
+			resourceUrl: "",
+			endpoint:    "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			endpoint := resourceEndpoint(tc.resourceUrl)
+			require.Equal(t, tc.endpoint, endpoint)
+		})
+	}
+}
+
+func TestValidateExpand(t *testing.T) {
+	testCases := []struct {
+		name   string
+		fields []string
+		err    error
+	}{
+		{
+			name:   "nil_fields",
+			fields: nil,
+			err:    nil,
+		},
+		{
+			name:   "valid_fields",
+			fields: []string{homeworldLink, filmsLink},
+			err:    nil,
+		},
+		{
+			name:   "not_a_link_field",
+			fields: []string{"name"},
+			err:    ErrInvalidExpandField,
+		},
+		{
+			name:   "link_field_of_another_resource",
+			fields: []string{residentsLink},
+			err:    ErrInvalidExpandField,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateExpand[Person](tc.fields)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestExpand(t *testing.T) {
+	var numRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		numRequests.Add(1)
+		switch r.URL.Path {
+		case "/planets/1/":
+			fmt.Fprint(w, `{"name":"Tatooine"}`)
+		case "/films/1/":
+			fmt.Fprint(w, `{"title":"A New Hope"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	useServer(t, server.URL)
+
+	homeworld := server.URL + "/planets/1/"
+	film := server.URL + "/films/1/"
+	missingFilm := server.URL + "/films/2/"
+	people := []Person{
+		{Name: "Luke Skywalker", Homeworld: homeworld, Films: []string{film, missingFilm}},
+		{Name: "C-3PO", Homeworld: homeworld, Films: []string{}},
+	}
+
+	err := expand(context.Background(), people, []string{homeworldLink, filmsLink})
+	require.NoError(t, err)
+
+	// Each linked resource must be requested only once.
+	require.Equal(t, int32(3), numRequests.Load())
+	require.Equal(t, Expansion{
+		homeworldLink: Planet{Name: "Tatooine"},
+		filmsLink:     []any{Film{Title: "A New Hope"}},
+	}, people[0].Expanded)
+	require.Equal(t, Expansion{
+		homeworldLink: Planet{Name: "Tatooine"},
+		filmsLink:     []any{},
+	}, people[1].Expanded)
+}
+
+func TestAddedFieldsOrder(t *testing.T) {
+	// The expanded resources and the score are added by the service, so they
+	// are marshaled after the fields SWAPI serves, which keep their order.
+	testCases := []struct {
+		name   string
+		fields []string
+	}{
+		{name: "person", fields: resourceFields[Person]()},
+		{name: "planet", fields: resourceFields[Planet]()},
+		{name: "film", fields: resourceFields[Film]()},
+		{name: "species", fields: resourceFields[Species]()},
+		{name: "vehicle", fields: resourceFields[Vehicle]()},
+		{name: "starship", fields: resourceFields[Starship]()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n := len(tc.fields)
+			require.Equal(t, []string{"url", "created", "edited", "expanded", "score"}, tc.fields[n-5:])
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package swapi
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	internalRequest "github.com/pegondo/starwars-service/internal/request"
+)
+
+// filmsEndpoint is the endpoint to request for films in SWAPI.
+const filmsEndpoint = "films"
+
+// Film represents a film in SWAPI.
+// Source: https://swapi.dev/documentation#films
+type Film struct {
+	// Title is the title of the film.
+	Title string `json:"title"`
+	// EpisodeId is the episode number of the film.
+	EpisodeId int `json:"episode_id"`
+	// OpeningCrawl is the opening paragraphs at the beginning of the film.
+	OpeningCrawl string `json:"opening_crawl"`
+	// Director is the name of the director of the film.
+	Director string `json:"director"`
+	// Producer is the name(s) of the producer(s) of the film. Comma separated.
+	Producer string `json:"producer"`
+	// ReleaseDate is the ISO 8601 date format of the film release at original
+	// creator country.
+	ReleaseDate string `json:"release_date"`
+	// Characters is the list of URLs of the people in this film.
+	Characters []string `json:"characters"`
+	// Planets is the list of URLs of the planets in this film.
+	Planets []string `json:"planets"`
+	// Starships is the list of URLs of the starships in this film.
+	Starships []string `json:"starships"`
+	// Vehicles is the list of URLs of the vehicles in this film.
+	Vehicles []string `json:"vehicles"`
+	// Species is the list of URLs of the species in this film.
+	Species []string `json:"species"`
+	// Url is the URL to the resource of this film.
+	Url string `json:"url"`
+	// Created is the time when the resource of this film was created.
+	Created time.Time `json:"created"`
+	// Edited is the time when the resource of this film was edited for the
+	// last time.
+	Edited time.Time `json:"edited"`
+	// Expanded contains the resources linked by the film that were
+	// requested to be expanded.
+	Expanded Expansion `json:"expanded,omitempty"`
+	// Score is the relevance of the film for the full-text search query. It's
+	// nil if the film wasn't found with a full-text search.
+	Score *float64 `json:"score,omitempty"`
+}
+
+// GetName returns the film title, as films have no name in SWAPI.
+func (f Film) GetName() string {
+	return f.Title
+}
+
+// GetCreated returns the film's resouce creation time in SWAPI.
+func (f Film) GetCreated() time.Time {
+	return f.Created
+}
+
+// GetUrl returns the URL of the film's resource in SWAPI.
+func (f Film) GetUrl() string {
+	return f.Url
+}
+
+// GetEdited returns the last time the film's resource was edited in SWAPI.
+func (f Film) GetEdited() time.Time {
+	return f.Edited
+}
+
+// fields returns the scalar fields of the film that can be filtered by.
+func (f Film) fields() fields {
+	return fields{
+		"title":        f.Title,
+		"episode_id":   strconv.Itoa(f.EpisodeId),
+		"director":     f.Director,
+		"producer":     f.Producer,
+		"release_date": f.ReleaseDate,
+	}
+}
+
+// links returns the link fields of the film.
+func (f Film) links() links {
+	return links{
+		charactersLink: f.Characters,
+		planetsLink:    f.Planets,
+		starshipsLink:  f.Starships,
+		vehiclesLink:   f.Vehicles,
+		speciesLink:    f.Species,
+	}
+}
+
+// longTexts returns the long text fields of the film, which are searched with
+// the full-text search.
+func (f Film) longTexts() fields {
+	return fields{
+		"opening_crawl": f.OpeningCrawl,
+	}
+}
+
+// setExpanded sets the expanded resources of the film.
+func (f *Film) setExpanded(expanded Expansion) {
+	f.Expanded = expanded
+}
+
+// setScore sets the relevance of the film for the full-text search query.
+func (f *Film) setScore(score float64) {
+	f.Score = &score
+}
+
+// rewriteUrls replaces the URLs of the film's resource, its links and its
+// expanded resources with the result of rewrite.
+func (f *Film) rewriteUrls(rewrite func(string) string) {
+	f.Url = rewrite(f.Url)
+	f.Characters = rewriteAll(f.Characters, rewrite)
+	f.Planets = rewriteAll(f.Planets, rewrite)
+	f.Starships = rewriteAll(f.Starships, rewrite)
+	f.Vehicles = rewriteAll(f.Vehicles, rewrite)
+	f.Species = rewriteAll(f.Species, rewrite)
+	f.Expanded = f.Expanded.rewriteUrls(rewrite)
+}
+
+// RetrieveFilms requests the SWAPI for films. The SWAPI doesn't support
+// pagination with variable page sizes, but this function does the maths and
+// requests the endpoint various times if needed to return the data for the
+// given page and page size. If params.Search is not "", the films returned will
+// contain the value of search in their title. If params.Sort isn't empty, the
+// films will be ordered with the defined criteria. The link fields in
+// params.Expand will be expanded.
+func RetrieveFilms(
+	ctx context.Context,
+	params internalRequest.RequestParams,
+) (
+	filmsResp SwapiResponse[Film],
+	err error,
+) {
+	return retrieveCollection[Film](ctx, filmsEndpoint, params)
+}
+
+// RetrieveFilmById requests the SWAPI for the film with the given id. If there
+// is no film with that id, RetrieveFilmById returns ErrNotFound. The given link
+// fields will be expanded.
+func RetrieveFilmById(ctx context.Context, id int, expandFields []string) (film Film, err error) {
+	return retrieveById[Film](ctx, filmsEndpoint, id, expandFields)
+}
+
+// RetrieveFilmCharacters requests the SWAPI for the characters of the film with
+// the given id. The search, sorting, pagination and expansion in params are
+// applied to the characters as in the top-level collections. If there is no
+// film with that id, RetrieveFilmCharacters returns ErrNotFound.
+func RetrieveFilmCharacters(
+	ctx context.Context,
+	id int,
+	params internalRequest.RequestParams,
+) (
+	charactersResp SwapiResponse[Person],
+	err error,
+) {
+	return retrieveLinkedCollection[Film, Person](ctx, filmsEndpoint, id, charactersLink, params)
+}
+
+// RetrieveFilmPlanets requests the SWAPI for the planets of the film with the
+// given id. The search, sorting, pagination and expansion in params are applied
+// to the planets as in the top-level collections. If there is no film with that
+// id, RetrieveFilmPlanets returns ErrNotFound.
+func RetrieveFilmPlanets(
+	ctx context.Context,
+	id int,
+	params internalRequest.RequestParams,
+) (
+	planetsResp SwapiResponse[Planet],
+	err error,
+) {
+	return retrieveLinkedCollection[Film, Planet](ctx, filmsEndpoint, id, planetsLink, params)
+}
+
+// RetrieveFilmStarships requests the SWAPI for the starships of the film with
+// the given id. The search, sorting, pagination and expansion in params are
+// applied to the starships as in the top-level collections. If there is no film
+// with that id, RetrieveFilmStarships returns ErrNotFound.
+func RetrieveFilmStarships(
+	ctx context.Context,
+	id int,
+	params internalRequest.RequestParams,
+) (
+	starshipsResp SwapiResponse[Starship],
+	err error,
+) {
+	return retrieveLinkedCollection[Film, Starship](ctx, filmsEndpoint, id, starshipsLink, params)
+}
+
+// RetrieveFilmVehicles requests the SWAPI for the vehicles of the film with the
+// given id. The search, sorting, pagination and expansion in params are applied
+// to the vehicles as in the top-level collections. If there is no film with
+// that id, RetrieveFilmVehicles returns ErrNotFound.
+func RetrieveFilmVehicles(
+	ctx context.Context,
+	id int,
+	params internalRequest.RequestParams,
+) (
+	vehiclesResp SwapiResponse[Vehicle],
+	err error,
+) {
+	return retrieveLinkedCollection[Film, Vehicle](ctx, filmsEndpoint, id, vehiclesLink, params)
+}
+
+// RetrieveFilmSpecies requests the SWAPI for the species of the film with the
+// given id. The search, sorting, pagination and expansion in params are applied
+// to the species as in the top-level collections. If there is no film with that
+// id, RetrieveFilmSpecies returns ErrNotFound.
+func RetrieveFilmSpecies(
+	ctx context.Context,
+	id int,
+	params internalRequest.RequestParams,
+) (
+	speciesResp SwapiResponse[Species],
+	err error,
+) {
+	return retrieveLinkedCollection[Film, Species](ctx, filmsEndpoint, id, speciesLink, params)
+}
@@ -0,0 +1,236 @@
+package swapi
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pegondo/starwars-service/internal/filterexpr"
+	internalRequest "github.com/pegondo/starwars-service/internal/request"
+	"github.com/pegondo/starwars-service/internal/utils"
+)
+
+// fieldValuesSeparator is the separator of the values of the fields that hold
+// a list of values, such as the climate of a planet: "arid, temperate".
+const fieldValuesSeparator = ","
+
+// numericFields are the fields that hold a number, although SWAPI serves them as
+// strings, so they can be used in the range filters.
+var numericFields = map[string]bool{
+	"average_height":         true,
+	"average_lifespan":       true,
+	"cargo_capacity":         true,
+	"cost_in_credits":        true,
+	"crew":                   true,
+	"diameter":               true,
+	"episode_id":             true,
+	"height":                 true,
+	"hyperdrive_rating":      true,
+	"length":                 true,
+	"mass":                   true,
+	"max_atmosphering_speed": true,
+	"mglt":                   true,
+	"orbital_period":         true,
+	"passengers":             true,
+	"population":             true,
+	"rotation_period":        true,
+	"surface_water":          true,
+}
+
+// fields maps the scalar fields of a resource, named as SWAPI names them but
+// lowercased, to their values.
+type fields map[string]string
+
+// MatchesText returns whether the given field has the given lowercased value,
+// as the equality filters compare them. It implements filterexpr.Record.
+func (f fields) MatchesText(field, value string) bool {
+	return matchesFilter(f[field], []string{value})
+}
+
+// Number returns the number in the given field, or false if it isn't known. It
+// implements filterexpr.Record.
+func (f fields) Number(field string) (float64, bool) {
+	return parseNumber(f[field])
+}
+
+// optionalField returns the value of a field that may be nil.
+func optionalField[S ~string](value *S) string {
+	if value == nil {
+		return ""
+	}
+	return string(*value)
+}
+
+// IsFilterField returns whether the given field is a field of the resource T
+// the resources can be filtered by, as it's named in the responses.
+func IsFilterField[T Resource](field string) bool {
+	var resource T
+	_, ok := resource.fields()[field]
+	return ok
+}
+
+// ValidateFilters validates that the fields of all the given filters are
+// fields of the resource T and that the range filters use numeric fields. If a
+// field isn't a field of the resource, ValidateFilters returns
+// ErrInvalidFilterField, and if a range filter uses a field that isn't
+// numeric, it returns ErrInvalidFilterOperator.
+func ValidateFilters[T Resource](filters []internalRequest.Filter) error {
+	var resource T
+	fields := resource.fields()
+	for _, filter := range filters {
+		if _, ok := fields[filter.Field]; !ok {
+			return ErrInvalidFilterField
+		}
+		if filter.Operator.IsRange() && !numericFields[filter.Field] {
+			return ErrInvalidFilterOperator
+		}
+	}
+	return nil
+}
+
+// ValidateFilterExpression validates that the given filter expression only
+// uses fields of the resource T and compares the numeric fields to numbers and
+// the rest to strings. If it doesn't, ValidateFilterExpression returns a
+// *filterexpr.Error with the position of the offending token.
+func ValidateFilterExpression[T Resource](expr filterexpr.Expr) error {
+	if expr == nil {
+		return nil
+	}
+	return expr.Check(filterSchema[T]())
+}
+
+// filterSchema returns the types of the fields of the resource T for the
+// filter expressions.
+func filterSchema[T Resource]() filterexpr.Schema {
+	var resource T
+	schema := filterexpr.Schema{}
+	for field := range resource.fields() {
+		if numericFields[field] {
+			schema[field] = filterexpr.NumberType
+		} else {
+			schema[field] = filterexpr.TextType
+		}
+	}
+	return schema
+}
+
+// hasFilters returns whether the given params request filtering the
+// resources, with filters or with a filter expression.
+func hasFilters(params internalRequest.RequestParams) bool {
+	return len(params.Filters) > 0 || params.FilterExpression != nil
+}
+
+// filterResults returns the resources that match all the filters and the
+// filter expression in the given params. The resources whose value isn't a
+// number match the range filters only if params.IncludeUnknown is true. The
+// given resources aren't modified.
+func filterResults[T Resource](resources []T, params internalRequest.RequestParams) []T {
+	if !hasFilters(params) {
+		return resources
+	}
+	filtered := []T{}
+	for _, resource := range resources {
+		fields := resource.fields()
+		if !matchesFilters(fields, params.Filters, params.IncludeUnknown) {
+			continue
+		}
+		if params.FilterExpression != nil && !params.FilterExpression.Eval(fields) {
+			continue
+		}
+		filtered = append(filtered, resource)
+	}
+	return filtered
+}
+
+// matchesFilters returns whether the given fields match all the given filters.
+func matchesFilters(fields fields, filters []internalRequest.Filter, includeUnknown bool) bool {
+	for _, filter := range filters {
+		value := fields[filter.Field]
+		if filter.Operator.IsRange() {
+			if !matchesRangeFilter(value, filter, includeUnknown) {
+				return false
+			}
+			continue
+		}
+		if !matchesFilter(value, filter.Values) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesRangeFilter returns whether the given field value compares to the
+// value of the given range filter as its operator requires. If the field value
+// isn't a number, matchesRangeFilter returns includeUnknown.
+func matchesRangeFilter(value string, filter internalRequest.Filter, includeUnknown bool) bool {
+	number, ok := parseNumber(value)
+	if !ok {
+		return includeUnknown
+	}
+	if len(filter.Values) == 0 {
+		return false
+	}
+	limit, err := strconv.ParseFloat(filter.Values[0], 64)
+	if err != nil {
+		return false
+	}
+	switch filter.Operator {
+	case internalRequest.GreaterThanOperator:
+		return number > limit
+	case internalRequest.GreaterOrEqualOperator:
+		return number >= limit
+	case internalRequest.LessThanOperator:
+		return number < limit
+	case internalRequest.LessOrEqualOperator:
+		return number <= limit
+	default:
+		return false
+	}
+}
+
+// parseNumber parses the number in the given SWAPI field value. SWAPI serves
+// the numbers as strings that may have thousands separators, e.g. "1,358", or
+// a trailing unit, e.g. "12500km" or "1 standard". The values that aren't a
+// single number, such as "unknown", "n/a", "none" or the ranges like "30-165",
+// aren't parsed and parseNumber returns false.
+func parseNumber(value string) (float64, bool) {
+	value = strings.TrimSpace(value)
+	// Remove the trailing unit, if any.
+	end := strings.IndexFunc(value, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.' && r != ',' && r != '-' && r != '+'
+	})
+	if end == 0 {
+		return 0, false
+	}
+	if end > 0 {
+		unit := strings.TrimSpace(value[end:])
+		if strings.IndexFunc(unit, func(r rune) bool { return !unicode.IsLetter(r) && r != ' ' }) >= 0 {
+			return 0, false
+		}
+		value = strings.TrimSpace(value[:end])
+	}
+	value = strings.ReplaceAll(value, ",", "")
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return number, true
+}
+
+// matchesFilter returns whether the given field value is any of the given case
+// folded values, ignoring the case. The values of the fields that hold a
+// list of values match if any of the values in the list does, so a planet with
+// a "arid, temperate" climate matches climate=arid.
+func matchesFilter(value string, values []string) bool {
+	value = utils.FoldCase(strings.TrimSpace(value))
+	if slices.Contains(values, value) {
+		return true
+	}
+	for _, item := range strings.Split(value, fieldValuesSeparator) {
+		if slices.Contains(values, strings.TrimSpace(item)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,295 @@
+package swapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pegondo/starwars-service/internal/filterexpr"
+	internalRequest "github.com/pegondo/starwars-service/internal/request"
+	"github.com/stretchr/testify/require"
+)
+
+// testPlanets returns planets with different climates, terrains, diameters and
+// populations.
+func testPlanets() []Planet {
+	return []Planet{
+		{Name: "Tatooine", Climate: "arid", Terrain: "desert", Diameter: "10465", Population: "200000", Url: "https://swapi.dev/api/planets/1/"},
+		{Name: "Alderaan", Climate: "temperate", Terrain: "grasslands, mountains", Diameter: "12500", Population: "2000000000", Url: "https://swapi.dev/api/planets/2/"},
+		{Name: "Geonosis", Climate: "temperate, arid", Terrain: "rock, desert, mountain, barren", Diameter: "11,370", Population: "100000000000", Url: "https://swapi.dev/api/planets/11/"},
+		{Name: "Hoth", Climate: "frozen", Terrain: "tundra, ice caves, mountain ranges", Diameter: "7200", Population: "unknown", Url: "https://swapi.dev/api/planets/4/"},
+	}
+}
+
+func TestValidateFilters(t *testing.T) {
+	testCases := []struct {
+		name    string
+		filters []internalRequest.Filter
+		err     error
+	}{
+		{
+			name:    "no_filters",
+			filters: nil,
+		},
+		{
+			name: "valid_fields",
+			filters: []internalRequest.Filter{
+				{Field: "climate", Values: []string{"arid"}},
+				{Field: "terrain", Values: []string{"desert"}},
+			},
+		},
+		{
+			name: "invalid_field",
+			filters: []internalRequest.Filter{
+				{Field: "gender", Values: []string{"female"}},
+			},
+			err: ErrInvalidFilterField,
+		},
+		{
+			name: "link_field",
+			filters: []internalRequest.Filter{
+				{Field: "residents", Values: []string{"https://swapi.dev/api/people/1/"}},
+			},
+			err: ErrInvalidFilterField,
+		},
+		{
+			name: "range_filter_on_numeric_field",
+			filters: []internalRequest.Filter{
+				{Field: "population", Operator: internalRequest.GreaterOrEqualOperator, Values: []string{"1000000"}},
+			},
+		},
+		{
+			name: "range_filter_on_non_numeric_field",
+			filters: []internalRequest.Filter{
+				{Field: "climate", Operator: internalRequest.GreaterThanOperator, Values: []string{"1"}},
+			},
+			err: ErrInvalidFilterOperator,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorIs(t, ValidateFilters[Planet](tc.filters), tc.err)
+		})
+	}
+}
+
+func TestFilterResults(t *testing.T) {
+	testCases := []struct {
+		name           string
+		filters        []internalRequest.Filter
+		includeUnknown bool
+		names          []string
+	}{
+		{
+			name:    "no_filters",
+			filters: nil,
+			names:   []string{"Tatooine", "Alderaan", "Geonosis", "Hoth"},
+		},
+		{
+			name: "single_value",
+			filters: []internalRequest.Filter{
+				{Field: "climate", Values: []string{"arid"}},
+			},
+			names: []string{"Tatooine", "Geonosis"},
+		},
+		{
+			name: "many_values",
+			filters: []internalRequest.Filter{
+				{Field: "climate", Values: []string{"frozen", "temperate"}},
+			},
+			names: []string{"Alderaan", "Geonosis", "Hoth"},
+		},
+		{
+			name: "many_filters",
+			filters: []internalRequest.Filter{
+				{Field: "climate", Values: []string{"temperate"}},
+				{Field: "terrain", Values: []string{"desert"}},
+			},
+			names: []string{"Geonosis"},
+		},
+		{
+			name: "whole_value",
+			filters: []internalRequest.Filter{
+				{Field: "terrain", Values: []string{"grasslands, mountains"}},
+			},
+			names: []string{"Alderaan"},
+		},
+		{
+			name: "no_matches",
+			filters: []internalRequest.Filter{
+				{Field: "climate", Values: []string{"murky"}},
+			},
+			names: []string{},
+		},
+		{
+			name: "greater_or_equal",
+			filters: []internalRequest.Filter{
+				{Field: "population", Operator: internalRequest.GreaterOrEqualOperator, Values: []string{"2000000000"}},
+			},
+			names: []string{"Alderaan", "Geonosis"},
+		},
+		{
+			name: "greater_than",
+			filters: []internalRequest.Filter{
+				{Field: "population", Operator: internalRequest.GreaterThanOperator, Values: []string{"2000000000"}},
+			},
+			names: []string{"Geonosis"},
+		},
+		{
+			name: "less_than_with_thousands_separator",
+			filters: []internalRequest.Filter{
+				{Field: "diameter", Operator: internalRequest.LessThanOperator, Values: []string{"11370"}},
+			},
+			names: []string{"Tatooine", "Hoth"},
+		},
+		{
+			name: "less_or_equal_with_thousands_separator",
+			filters: []internalRequest.Filter{
+				{Field: "diameter", Operator: internalRequest.LessOrEqualOperator, Values: []string{"11370"}},
+			},
+			names: []string{"Tatooine", "Geonosis", "Hoth"},
+		},
+		{
+			name: "range_and_equal_filters",
+			filters: []internalRequest.Filter{
+				{Field: "climate", Values: []string{"arid"}},
+				{Field: "diameter", Operator: internalRequest.GreaterThanOperator, Values: []string{"10500"}},
+			},
+			names: []string{"Geonosis"},
+		},
+		{
+			name: "unknown_values_excluded",
+			filters: []internalRequest.Filter{
+				{Field: "population", Operator: internalRequest.LessThanOperator, Values: []string{"1000000"}},
+			},
+			names: []string{"Tatooine"},
+		},
+		{
+			name: "unknown_values_included",
+			filters: []internalRequest.Filter{
+				{Field: "population", Operator: internalRequest.LessThanOperator, Values: []string{"1000000"}},
+			},
+			includeUnknown: true,
+			names:          []string{"Tatooine", "Hoth"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			names := []string{}
+			for _, planet := range filterResults(testPlanets(), internalRequest.RequestParams{
+				Filters:        tc.filters,
+				IncludeUnknown: tc.includeUnknown,
+			}) {
+				names = append(names, planet.Name)
+			}
+			require.Equal(t, tc.names, names)
+		})
+	}
+}
+
+func TestFilterResults_Expression(t *testing.T) {
+	testCases := []struct {
+		name    string
+		filter  string
+		filters []internalRequest.Filter
+		names   []string
+	}{
+		{
+			name:   "nested",
+			filter: `climate eq "arid" and (diameter gt 11000 or population lt 1000000)`,
+			names:  []string{"Tatooine", "Geonosis"},
+		},
+		{
+			name:   "list_field_and_thousands_separator",
+			filter: `terrain in ("mountains", "tundra") or diameter eq 11370`,
+			names:  []string{"Alderaan", "Geonosis", "Hoth"},
+		},
+		{
+			name:   "expression_and_filters",
+			filter: `not climate eq "frozen"`,
+			filters: []internalRequest.Filter{
+				{Field: "terrain", Values: []string{"desert"}},
+			},
+			names: []string{"Tatooine", "Geonosis"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expr, err := filterexpr.Parse(tc.filter)
+			require.NoError(t, err)
+			require.NoError(t, ValidateFilterExpression[Planet](expr))
+
+			names := []string{}
+			for _, planet := range filterResults(testPlanets(), internalRequest.RequestParams{
+				Filters:          tc.filters,
+				FilterExpression: expr,
+			}) {
+				names = append(names, planet.Name)
+			}
+			require.Equal(t, tc.names, names)
+		})
+	}
+}
+
+func TestValidateFilterExpression(t *testing.T) {
+	require.NoError(t, ValidateFilterExpression[Planet](nil))
+
+	expr, err := filterexpr.Parse(`population gt 0 and gender eq "female"`)
+	require.NoError(t, err)
+	err = ValidateFilterExpression[Planet](expr)
+	require.Equal(t, &filterexpr.Error{Pos: 21, Msg: `unknown field "gender"`}, err)
+
+	expr, err = filterexpr.Parse(`climate gte 1`)
+	require.NoError(t, err)
+	err = ValidateFilterExpression[Planet](expr)
+	require.Equal(t, &filterexpr.Error{Pos: 9, Msg: "operator gte needs a numeric field, but climate is a text field"}, err)
+}
+
+func TestParseNumber(t *testing.T) {
+	testCases := []struct {
+		value  string
+		number float64
+		ok     bool
+	}{
+		{value: "172", number: 172, ok: true},
+		{value: "1,358", number: 1358, ok: true},
+		{value: "1000000000000", number: 1e12, ok: true},
+		{value: "0.75", number: 0.75, ok: true},
+		{value: " 40 ", number: 40, ok: true},
+		{value: "12500km", number: 12500, ok: true},
+		{value: "1 standard", number: 1, ok: true},
+		{value: "unknown", ok: false},
+		{value: "n/a", ok: false},
+		{value: "none", ok: false},
+		{value: "indefinite", ok: false},
+		{value: "", ok: false},
+		{value: "30-165", ok: false},
+		{value: "1.5 / 2", ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			number, ok := parseNumber(tc.value)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.number, number)
+		})
+	}
+}
+
+func TestRetrievePlanets_Filters(t *testing.T) {
+	ctx := WithBackends(context.Background(), MemoryBackends(Dataset{Planets: testPlanets()}))
+
+	planets, err := RetrievePlanets(ctx, internalRequest.RequestParams{
+		Page:     1,
+		PageSize: 1,
+		Filters: []internalRequest.Filter{
+			{Field: "terrain", Values: []string{"desert"}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, planets.Count)
+	require.Len(t, planets.Results, 1)
+	require.Equal(t, "Tatooine", planets.Results[0].Name)
+}
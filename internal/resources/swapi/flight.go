@@ -0,0 +1,109 @@
+package swapi
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pegondo/starwars-service/internal/logger"
+)
+
+// flightCall represents an in-flight or finished call of a flightGroup.
+type flightCall[V any] struct {
+	// done is closed when the call finishes.
+	done  chan struct{}
+	value V
+	err   error
+	// dups is the number of callers that joined the call while in flight.
+	dups int
+	// waiters is the number of callers still waiting for the call.
+	waiters int
+	// cancel cancels the context the call runs with.
+	cancel context.CancelFunc
+}
+
+// flightGroup deduplicates concurrent calls with the same key: while a call is
+// in flight, the callers asking for the same key wait for it and share its
+// result instead of performing their own call.
+type flightGroup[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*flightCall[V]
+}
+
+// do executes fn and returns its result, making sure only one execution for
+// the given key is in flight at a time. If there is already one in flight, do
+// waits for it to finish and returns its result. shared reports whether the
+// result was given to more than one caller.
+//
+// As the result may be shared, fn doesn't run with ctx but with a context that
+// only carries the logger of the caller that started the call, see
+// logger.FromContext, and that is only canceled once all the callers have
+// stopped waiting, so fn must bound its own duration. If ctx
+// is done before the call finishes, do stops waiting and returns the context
+// error. The call keeps running for the rest of the callers, if any.
+func (g *flightGroup[K, V]) do(
+	ctx context.Context,
+	key K,
+	fn func(ctx context.Context) (V, error),
+) (
+	value V,
+	err error,
+	shared bool,
+) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[K]*flightCall[V]{}
+	}
+	call, exists := g.calls[key]
+	if exists {
+		call.dups++
+		call.waiters++
+	} else {
+		callCtx, cancel := context.WithCancel(logger.NewContext(context.Background(), logger.FromContext(ctx)))
+		call = &flightCall[V]{done: make(chan struct{}), waiters: 1, cancel: cancel}
+		g.calls[key] = call
+		go g.run(callCtx, key, call, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		g.leave(key, call)
+		return value, ctx.Err(), false
+	}
+
+	g.mu.Lock()
+	shared = exists || call.dups > 0
+	g.mu.Unlock()
+	return call.value, call.err, shared
+}
+
+// leave removes a caller that stopped waiting from the given call of the
+// group. If no caller is left waiting, the call is canceled and removed from
+// the group, so the next callers don't join it.
+func (g *flightGroup[K, V]) leave(key K, call *flightCall[V]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	call.waiters--
+	if call.waiters > 0 {
+		return
+	}
+	call.cancel()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
+
+// run executes fn for the given call of the group and notifies its callers
+// when it finishes.
+func (g *flightGroup[K, V]) run(ctx context.Context, key K, call *flightCall[V], fn func(ctx context.Context) (V, error)) {
+	call.value, call.err = fn(ctx)
+
+	g.mu.Lock()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+	call.cancel()
+	close(call.done)
+}
@@ -0,0 +1,158 @@
+package swapi
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pegondo/starwars-service/internal/logger"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlightGroupDo(t *testing.T) {
+	var g flightGroup[string, int]
+	var numCalls atomic.Int32
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	fn := func(context.Context) (int, error) {
+		if numCalls.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return 42, nil
+	}
+
+	const numCallers = 10
+	var wg sync.WaitGroup
+	values := make([]int, numCallers)
+
+	// Start the first call and wait for it to be in flight before the rest.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		values[0], _, _ = g.do(context.Background(), "<key>", fn)
+	}()
+	<-started
+	for i := 1; i < numCallers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			values[i], _, _ = g.do(context.Background(), "<key>", fn)
+		}()
+	}
+	// Wait for all the callers to join the in-flight call.
+	require.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return g.calls["<key>"].dups == numCallers-1
+	}, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), numCalls.Load())
+	for _, value := range values {
+		require.Equal(t, 42, value)
+	}
+}
+
+func TestFlightGroupDo_DifferentKeys(t *testing.T) {
+	var g flightGroup[string, string]
+
+	value1, _, shared1 := g.do(context.Background(), "<key-1>", func(context.Context) (string, error) { return "<value-1>", nil })
+	value2, _, shared2 := g.do(context.Background(), "<key-2>", func(context.Context) (string, error) { return "<value-2>", nil })
+
+	require.Equal(t, "<value-1>", value1)
+	require.Equal(t, "<value-2>", value2)
+	require.False(t, shared1)
+	require.False(t, shared2)
+}
+
+func TestFlightGroupDo_Logger(t *testing.T) {
+	var g flightGroup[string, string]
+	var logs bytes.Buffer
+	ctx := logger.NewContext(context.Background(), zerolog.New(&logs).With().Str("reqId", "<req-id>").Logger())
+
+	_, err, _ := g.do(ctx, "<key>", func(ctx context.Context) (string, error) {
+		l := logger.FromContext(ctx)
+		l.Info().Msg("<message>")
+		return "", nil
+	})
+	require.NoError(t, err)
+	// The call must log with the logger of the caller that started it.
+	require.Contains(t, logs.String(), `"reqId":"<req-id>"`)
+	require.Contains(t, logs.String(), `"message":"<message>"`)
+}
+
+func TestFlightGroupDo_Canceled(t *testing.T) {
+	var g flightGroup[string, int]
+	started := make(chan struct{})
+	finished := make(chan struct{})
+	var callErr error
+	fn := func(ctx context.Context) (int, error) {
+		defer close(finished)
+		close(started)
+		<-ctx.Done()
+		callErr = ctx.Err()
+		return 0, callErr
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	_, err, _ := g.do(ctx, "<key>", fn)
+	require.ErrorIs(t, err, context.Canceled)
+
+	<-finished
+	// The only caller stopped waiting, so the call must be canceled.
+	require.ErrorIs(t, callErr, context.Canceled)
+	// The canceled call must be removed from the group.
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	require.Empty(t, g.calls)
+}
+
+func TestFlightGroupDo_OneCallerCanceled(t *testing.T) {
+	var g flightGroup[string, int]
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var callErr error
+	fn := func(ctx context.Context) (int, error) {
+		close(started)
+		<-release
+		callErr = ctx.Err()
+		return 42, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceledErr := make(chan error)
+	go func() {
+		_, err, _ := g.do(ctx, "<key>", fn)
+		canceledErr <- err
+	}()
+	<-started
+
+	value := make(chan int)
+	go func() {
+		v, _, _ := g.do(context.Background(), "<key>", fn)
+		value <- v
+	}()
+	require.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return g.calls["<key>"].dups == 1
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	require.ErrorIs(t, <-canceledErr, context.Canceled)
+	close(release)
+	// The other caller is still waiting, so the call must not be canceled.
+	require.Equal(t, 42, <-value)
+	require.NoError(t, callErr)
+}
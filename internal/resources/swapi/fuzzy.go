@@ -0,0 +1,98 @@
+package swapi
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/pegondo/starwars-service/internal/fuzzy"
+	"github.com/pegondo/starwars-service/internal/utils"
+)
+
+const (
+	// minNameSimilarity is the similarity a name must have with the search to
+	// match a fuzzy search or to be suggested, from 0 to 1.
+	minNameSimilarity = 0.6
+	// maxSuggestions is the maximum number of names suggested when no name
+	// contains the search.
+	maxSuggestions = 3
+)
+
+// similarResource is a resource and the similarity of its name to a search.
+type similarResource[T Resource] struct {
+	resource   T
+	similarity float64
+}
+
+// similarResources returns the given resources whose name is similar to
+// search, from the most to the least similar. The names that contain search
+// are the most similar, and the resources equally similar keep their order.
+func similarResources[T Resource](resources []T, search string) []similarResource[T] {
+	similar := []similarResource[T]{}
+	for _, resource := range resources {
+		similarity := fuzzy.Similarity(search, resource.GetName())
+		if similarity >= minNameSimilarity {
+			similar = append(similar, similarResource[T]{resource: resource, similarity: similarity})
+		}
+	}
+	slices.SortStableFunc(similar, func(a, b similarResource[T]) int {
+		return cmp.Compare(b.similarity, a.similarity)
+	})
+	return similar
+}
+
+// fuzzyFilterByName returns the resources whose name is similar to search,
+// tolerating typos such as "skywaker" for "Skywalker", from the most to the
+// least similar. If search is "", fuzzyFilterByName returns all the resources.
+func fuzzyFilterByName[T Resource](resources []T, search string) []T {
+	if search == "" {
+		return resources
+	}
+	filtered := []T{}
+	for _, similar := range similarResources(resources, search) {
+		filtered = append(filtered, similar.resource)
+	}
+	return filtered
+}
+
+// keepSimilarByName returns the resources whose name is similar to search, as
+// fuzzyFilterByName does, but in their original order. If search is "",
+// keepSimilarByName returns all the resources.
+func keepSimilarByName[T Resource](resources []T, search string) []T {
+	if search == "" {
+		return resources
+	}
+	filtered := []T{}
+	for _, resource := range resources {
+		if fuzzy.Similarity(search, resource.GetName()) >= minNameSimilarity {
+			filtered = append(filtered, resource)
+		}
+	}
+	return filtered
+}
+
+// nameSuggestions returns up to maxSuggestions names of the given resources
+// similar to search, from the most to the least similar, to suggest them when
+// no name contains search, ignoring the case. If any name contains search, or
+// none is similar enough, nameSuggestions returns nil.
+func nameSuggestions[T Resource](resources []T, search string) []string {
+	search = utils.FoldCase(search)
+	for _, resource := range resources {
+		if strings.Contains(utils.FoldCase(resource.GetName()), search) {
+			return nil
+		}
+	}
+
+	var suggestions []string
+	for _, similar := range similarResources(resources, search) {
+		name := similar.resource.GetName()
+		if slices.Contains(suggestions, name) {
+			continue
+		}
+		suggestions = append(suggestions, name)
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+	}
+	return suggestions
+}
@@ -0,0 +1,241 @@
+package swapi
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	internalRequest "github.com/pegondo/starwars-service/internal/request"
+	"github.com/stretchr/testify/require"
+)
+
+// testFuzzyPeople returns the people used to test the fuzzy search.
+func testFuzzyPeople() []Person {
+	return []Person{
+		{Name: "Luke Skywalker", Url: "https://swapi.dev/api/people/1/"},
+		{Name: "Anakin Skywalker", Url: "https://swapi.dev/api/people/11/"},
+		{Name: "Shmi Skywalker", Url: "https://swapi.dev/api/people/43/"},
+		{Name: "Leia Organa", Url: "https://swapi.dev/api/people/5/"},
+		{Name: "Yoda", Url: "https://swapi.dev/api/people/20/"},
+	}
+}
+
+func TestFuzzyFilterByName(t *testing.T) {
+	testCases := []struct {
+		name   string
+		search string
+		names  []string
+	}{
+		{
+			name:   "no_search",
+			search: "",
+			names:  []string{"Luke Skywalker", "Anakin Skywalker", "Shmi Skywalker", "Leia Organa", "Yoda"},
+		},
+		{
+			name:   "typo",
+			search: "skywaker",
+			names:  []string{"Luke Skywalker", "Anakin Skywalker", "Shmi Skywalker"},
+		},
+		{
+			name:   "most_similar_first",
+			search: "luke skywaker",
+			names:  []string{"Luke Skywalker", "Shmi Skywalker", "Anakin Skywalker"},
+		},
+		{
+			name:   "contained_first",
+			search: "yod",
+			names:  []string{"Yoda"},
+		},
+		{
+			name:   "nothing_similar",
+			search: "chewbacca",
+			names:  []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			names := []string{}
+			for _, person := range fuzzyFilterByName(testFuzzyPeople(), tc.search) {
+				names = append(names, person.Name)
+			}
+			require.Equal(t, tc.names, names)
+		})
+	}
+}
+
+func TestNameSuggestions(t *testing.T) {
+	testCases := []struct {
+		name        string
+		search      string
+		suggestions []string
+	}{
+		{
+			name:        "exact_match",
+			search:      "sky",
+			suggestions: nil,
+		},
+		{
+			name:        "typo",
+			search:      "yodda",
+			suggestions: []string{"Yoda"},
+		},
+		{
+			name:        "at_most_max_suggestions",
+			search:      "skywaler",
+			suggestions: []string{"Luke Skywalker", "Anakin Skywalker", "Shmi Skywalker"},
+		},
+		{
+			name:        "nothing_similar",
+			search:      "chewbacca",
+			suggestions: nil,
+		},
+	}
+
+	people := append(testFuzzyPeople(), Person{Name: "Owen Skywalker", Url: "https://swapi.dev/api/people/6/"})
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.suggestions, nameSuggestions(people, tc.search))
+		})
+	}
+}
+
+func TestRetrievePeople_Fuzzy(t *testing.T) {
+	ctx := WithBackends(context.Background(), MemoryBackends(Dataset{People: testFuzzyPeople()}))
+
+	testCases := []struct {
+		name        string
+		params      internalRequest.RequestParams
+		count       int
+		names       []string
+		suggestions []string
+	}{
+		{
+			name: "exact_search_without_results",
+			params: internalRequest.RequestParams{
+				Page:     1,
+				PageSize: 10,
+				Search:   "yodda",
+			},
+			count:       0,
+			names:       []string{},
+			suggestions: []string{"Yoda"},
+		},
+		{
+			name: "fuzzy_search",
+			params: internalRequest.RequestParams{
+				Page:     1,
+				PageSize: 2,
+				Search:   "skywaker",
+				Fuzzy:    true,
+			},
+			count:       3,
+			names:       []string{"Luke Skywalker", "Anakin Skywalker"},
+			suggestions: []string{"Luke Skywalker", "Anakin Skywalker", "Shmi Skywalker"},
+		},
+		{
+			name: "fuzzy_search_with_exact_results",
+			params: internalRequest.RequestParams{
+				Page:     1,
+				PageSize: 10,
+				Search:   "leia",
+				Fuzzy:    true,
+			},
+			count: 1,
+			names: []string{"Leia Organa"},
+		},
+		{
+			name: "sorted_fuzzy_search",
+			params: internalRequest.RequestParams{
+				Page:     1,
+				PageSize: 10,
+				Search:   "skywaker",
+				Fuzzy:    true,
+				Sort: []internalRequest.SortCriteria{
+					{Field: internalRequest.NameSortField, Order: internalRequest.DescendingOrder},
+				},
+			},
+			count:       3,
+			names:       []string{"Shmi Skywalker", "Luke Skywalker", "Anakin Skywalker"},
+			suggestions: []string{"Luke Skywalker", "Anakin Skywalker", "Shmi Skywalker"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			people, err := RetrievePeople(ctx, tc.params)
+			require.NoError(t, err)
+			require.Equal(t, tc.count, people.Count)
+			require.Equal(t, tc.suggestions, people.Suggestions)
+
+			names := []string{}
+			for _, person := range people.Results {
+				names = append(names, person.Name)
+			}
+			require.Equal(t, tc.names, names)
+		})
+	}
+}
+
+// countingBackend is a Backend that counts the pages it lists.
+type countingBackend[T Resource] struct {
+	Backend[T]
+	numLists *atomic.Int32
+}
+
+func (b countingBackend[T]) List(ctx context.Context, pageNumber int) (SwapiResponse[T], error) {
+	b.numLists.Add(1)
+	return b.Backend.List(ctx, pageNumber)
+}
+
+func TestRetrievePeople_FuzzySuggestionsReuseCollection(t *testing.T) {
+	var numLists atomic.Int32
+	people := countingBackend[Person]{
+		Backend:  MemoryBackends(Dataset{People: testFuzzyPeople()}).People,
+		numLists: &numLists,
+	}
+	ctx := WithBackends(context.Background(), Backends{People: people})
+
+	resp, err := RetrievePeople(ctx, internalRequest.RequestParams{
+		Page:     1,
+		PageSize: 10,
+		Search:   "skywaker",
+		Fuzzy:    true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Luke Skywalker", "Anakin Skywalker", "Shmi Skywalker"}, resp.Suggestions)
+	// The suggestions must come from the collection retrieved for the
+	// results, which fits in a single page.
+	require.Equal(t, int32(1), numLists.Load())
+}
+
+func TestRetrievePeople_FuzzyFullTextSearch(t *testing.T) {
+	black, blond := "black", "blond"
+	dataset := Dataset{People: []Person{
+		{Name: "Luke Skywalker", HairColor: &blond, Url: "https://swapi.dev/api/people/1/"},
+		{Name: "Anakin Skywalker", HairColor: &black, Url: "https://swapi.dev/api/people/11/"},
+		{Name: "Shmi Skywalker", HairColor: &black, EyeColor: &black, Url: "https://swapi.dev/api/people/43/"},
+		{Name: "Yoda", HairColor: &black, EyeColor: &black, Url: "https://swapi.dev/api/people/20/"},
+	}}
+	ctx := WithBackends(context.Background(), MemoryBackends(dataset))
+
+	// Anakin's name is the most similar to the search, but Shmi's is more
+	// relevant for the query, so Shmi goes first. Yoda is relevant for the
+	// query but not similar to the search, and Luke isn't relevant.
+	resp, err := RetrievePeople(ctx, internalRequest.RequestParams{
+		Page:     1,
+		PageSize: 10,
+		Search:   "anakin skywaker",
+		Fuzzy:    true,
+		Query:    "black",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, resp.Count)
+
+	names := []string{}
+	for _, person := range resp.Results {
+		names = append(names, person.Name)
+	}
+	require.Equal(t, []string{"Shmi Skywalker", "Anakin Skywalker"}, names)
+	require.Greater(t, *resp.Results[0].Score, *resp.Results[1].Score)
+}
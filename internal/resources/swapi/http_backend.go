@@ -0,0 +1,57 @@
+package swapi
+
+import (
+	"context"
+	"errors"
+)
+
+// httpBackend is the Backend that requests the resources of type T to the
+// SWAPI HTTP API in swapiBaseUrl.
+type httpBackend[T Resource] struct {
+	// endpoint is the SWAPI endpoint of the resources.
+	endpoint string
+}
+
+// HttpBackends returns the backends that request the resources to the SWAPI
+// HTTP API.
+func HttpBackends() Backends {
+	return Backends{
+		People:    httpBackend[Person]{endpoint: peopleEndpoint},
+		Planets:   httpBackend[Planet]{endpoint: planetsEndpoint},
+		Films:     httpBackend[Film]{endpoint: filmsEndpoint},
+		Species:   httpBackend[Species]{endpoint: speciesEndpoint},
+		Vehicles:  httpBackend[Vehicle]{endpoint: vehiclesEndpoint},
+		Starships: httpBackend[Starship]{endpoint: starshipsEndpoint},
+	}
+}
+
+// List requests the page with the given number of the SWAPI endpoint.
+func (b httpBackend[T]) List(ctx context.Context, pageNumber int) (SwapiResponse[T], error) {
+	return b.requestPage(ctx, pageNumber, "")
+}
+
+// Search requests the page with the given number of the SWAPI endpoint with the
+// given search condition.
+func (b httpBackend[T]) Search(ctx context.Context, search string, pageNumber int) (SwapiResponse[T], error) {
+	return b.requestPage(ctx, pageNumber, search)
+}
+
+// Get requests the resource with the given id to the SWAPI endpoint.
+func (b httpBackend[T]) Get(ctx context.Context, id int) (T, error) {
+	return requestResource[T](ctx, buildResourceUrl(b.endpoint, id))
+}
+
+// requestPage requests the page with the given number of the SWAPI endpoint.
+// If search isn't "", the resources returned will contain the value of search
+// in their name. If the page doesn't exist, requestPage returns an empty
+// response, as SWAPI does when the search doesn't match any resource.
+func (b httpBackend[T]) requestPage(ctx context.Context, pageNumber int, search string) (resp SwapiResponse[T], err error) {
+	resp, err = request[T](ctx, buildUrl(b.endpoint, pageNumber, search))
+	if errors.Is(err, ErrNotFound) {
+		return SwapiResponse[T]{
+			Count:   0,
+			Results: []T{},
+		}, nil
+	}
+	return resp, err
+}
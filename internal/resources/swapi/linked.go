@@ -0,0 +1,119 @@
+package swapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	internalRequest "github.com/pegondo/starwars-service/internal/request"
+	"github.com/pegondo/starwars-service/internal/utils"
+)
+
+// requestLinkedResources requests the resources of type T with the given URLs
+// concurrently and returns them in the same order as the URLs. The resources
+// SWAPI doesn't find are left out.
+func requestLinkedResources[T Resource](ctx context.Context, urls []string) (resources []T, err error) {
+	linked, err := requestAllLinked(ctx, urls)
+	if err != nil {
+		return nil, err
+	}
+
+	resources = make([]T, 0, len(urls))
+	for _, resourceUrl := range urls {
+		if resource, ok := linked[resourceUrl].(T); ok {
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+// modeled is implemented by the resources SWAPI searches by their model
+// besides by their name, such as the vehicles and the starships.
+type modeled interface {
+	model() string
+}
+
+// filterByName returns the resources whose name contains search, ignoring the
+// case, or whose model does for the resources with a model, as SWAPI searches
+// them. search must be case folded, see utils.FoldCase.
+func filterByName[T Resource](resources []T, search string) []T {
+	if search == "" {
+		return resources
+	}
+	filtered := []T{}
+	for _, resource := range resources {
+		if matchesSearch(resource, search) {
+			filtered = append(filtered, resource)
+		}
+	}
+	return filtered
+}
+
+// matchesSearch returns whether the name of the given resource contains
+// search, ignoring the case, or its model does if it has one. search must be
+// case folded, see utils.FoldCase.
+func matchesSearch[T Resource](resource T, search string) bool {
+	if strings.Contains(utils.FoldCase(resource.GetName()), search) {
+		return true
+	}
+	m, ok := any(resource).(modeled)
+	return ok && strings.Contains(utils.FoldCase(m.model()), search)
+}
+
+// retrieveLinkedCollection retrieves the resources of type T linked in the
+// given link field of the resource of type P with the given id in the given
+// SWAPI endpoint. The search, filters, sorting, pagination and expansion in
+// params are applied to the linked resources as in the top-level collections,
+// including the fuzzy search and the suggestions.
+// If SWAPI doesn't have a resource with that id, retrieveLinkedCollection
+// returns ErrNotFound.
+func retrieveLinkedCollection[P Resource, T Resource](
+	ctx context.Context,
+	endpoint string,
+	id int,
+	field string,
+	params internalRequest.RequestParams,
+) (
+	resp SwapiResponse[T],
+	err error,
+) {
+	parent, err := retrieveById[P](ctx, endpoint, id, nil)
+	if err != nil {
+		return resp, err
+	}
+	urls := parent.links()[field]
+
+	// All the linked resources are requested, even if only a page of them is
+	// needed, as the ones SWAPI doesn't find are left out of the count.
+	results, err := requestLinkedResources[T](ctx, urls)
+	if err != nil {
+		return resp, fmt.Errorf("error while requesting the %s of the %s endpoint :: %w", field, endpoint, err)
+	}
+	linked := results
+	if params.Fuzzy {
+		results = fuzzyFilterByName(results, params.Search)
+	} else {
+		results = filterByName(results, utils.FoldCase(params.Search))
+	}
+	if params.Query != "" {
+		results = searchResults("", results, params.Query)
+	}
+	results = filterResults(results, params)
+	if len(params.Sort) > 0 {
+		if err = SortResults(results, params.Sort...); err != nil {
+			return resp, err
+		}
+	}
+	resp = SwapiResponse[T]{
+		Count:   len(results),
+		Results: paginate(results, params.Page, params.PageSize),
+	}
+	if params.Search != "" {
+		resp.Suggestions = nameSuggestions(linked, params.Search)
+	}
+
+	if err = expand(ctx, resp.Results, params.Expand); err != nil {
+		return resp, fmt.Errorf("error while expanding the %s of the %s endpoint :: %w", field, endpoint, err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,125 @@
+package swapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	internalRequest "github.com/pegondo/starwars-service/internal/request"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrieveLinkedCollection(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/planets/1/":
+			fmt.Fprintf(w, `{"name":"Tatooine","residents":["%[1]s/people/1/","%[1]s/people/2/","%[1]s/people/3/"]}`, server.URL)
+		case "/planets/3/":
+			fmt.Fprintf(w, `{"name":"Alderaan","residents":["%[1]s/people/1/","%[1]s/people/9/","%[1]s/people/2/"]}`, server.URL)
+		case "/people/1/":
+			fmt.Fprint(w, `{"name":"Luke Skywalker"}`)
+		case "/people/2/":
+			fmt.Fprint(w, `{"name":"C-3PO"}`)
+		case "/people/3/":
+			fmt.Fprint(w, `{"name":"Darth Vader"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	useServer(t, server.URL)
+
+	testCases := []struct {
+		name   string
+		id     int
+		params internalRequest.RequestParams
+		resp   SwapiResponse[Person]
+		err    error
+	}{
+		{
+			name:   "first_page",
+			id:     1,
+			params: internalRequest.RequestParams{Page: 1, PageSize: 2},
+			resp: SwapiResponse[Person]{
+				Count:   3,
+				Results: []Person{{Name: "Luke Skywalker"}, {Name: "C-3PO"}},
+			},
+		},
+		{
+			name:   "second_page",
+			id:     1,
+			params: internalRequest.RequestParams{Page: 2, PageSize: 2},
+			resp: SwapiResponse[Person]{
+				Count:   3,
+				Results: []Person{{Name: "Darth Vader"}},
+			},
+		},
+		{
+			name:   "search",
+			id:     1,
+			params: internalRequest.RequestParams{Page: 1, PageSize: 2, Search: "sky"},
+			resp: SwapiResponse[Person]{
+				Count:   1,
+				Results: []Person{{Name: "Luke Skywalker"}},
+			},
+		},
+		{
+			name:   "capitalized_search",
+			id:     1,
+			params: internalRequest.RequestParams{Page: 1, PageSize: 2, Search: "SKY"},
+			resp: SwapiResponse[Person]{
+				Count:   1,
+				Results: []Person{{Name: "Luke Skywalker"}},
+			},
+		},
+		{
+			name: "sort_by_name_desc",
+			id:   1,
+			params: internalRequest.RequestParams{
+				Page:     1,
+				PageSize: 2,
+				Sort: []internalRequest.SortCriteria{
+					{
+						Field: internalRequest.NameSortField,
+						Order: internalRequest.DescendingOrder,
+					},
+				},
+			},
+			resp: SwapiResponse[Person]{
+				Count:   3,
+				Results: []Person{{Name: "Luke Skywalker"}, {Name: "Darth Vader"}},
+			},
+		},
+		{
+			// The residents SWAPI doesn't find are left out of the count, so
+			// the page holds the whole sub-collection.
+			name:   "missing_resident",
+			id:     3,
+			params: internalRequest.RequestParams{Page: 1, PageSize: 2},
+			resp: SwapiResponse[Person]{
+				Count:   2,
+				Results: []Person{{Name: "Luke Skywalker"}, {Name: "C-3PO"}},
+			},
+		},
+		{
+			name:   "missing_planet",
+			id:     2,
+			params: internalRequest.RequestParams{Page: 1, PageSize: 2},
+			err:    ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := RetrievePlanetResidents(context.Background(), tc.id, tc.params)
+			require.ErrorIs(t, err, tc.err)
+			if tc.err == nil {
+				require.Equal(t, tc.resp, resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package swapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pegondo/starwars-service/internal/utils"
+)
+
+// Dataset holds every resource the API serves, as SWAPI returns them.
+type Dataset struct {
+	People    []Person   `json:"people"`
+	Planets   []Planet   `json:"planets"`
+	Films     []Film     `json:"films"`
+	Species   []Species  `json:"species"`
+	Vehicles  []Vehicle  `json:"vehicles"`
+	Starships []Starship `json:"starships"`
+}
+
+// empty returns whether the dataset has no resources.
+func (d Dataset) empty() bool {
+	return len(d.People) == 0 && len(d.Planets) == 0 && len(d.Films) == 0 &&
+		len(d.Species) == 0 && len(d.Vehicles) == 0 && len(d.Starships) == 0
+}
+
+// LoadDataset reads the JSON dataset in the given path, which can be a plain
+// dataset or a snapshot written by WriteSnapshot. If the snapshot has a newer
+// format version than the supported one, LoadDataset returns an error.
+func LoadDataset(path string) (dataset Dataset, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return dataset, fmt.Errorf("error while reading the dataset file :: %v", err)
+	}
+	var snapshot Snapshot
+	if err = json.Unmarshal(content, &snapshot); err != nil {
+		return dataset, fmt.Errorf("error while parsing the dataset file :: %v", err)
+	}
+	if snapshot.Version > snapshotVersion {
+		return dataset, fmt.Errorf("unsupported snapshot version %d, the latest supported version is %d", snapshot.Version, snapshotVersion)
+	}
+	return snapshot.Dataset, nil
+}
+
+// memoryBackend is the Backend that serves the resources of type T from
+// memory.
+type memoryBackend[T Resource] struct {
+	resources []T
+	// byId indexes the resources by the id in their URL.
+	byId map[int]T
+}
+
+// newMemoryBackend creates and returns a backend that serves the given
+// resources. The resources whose URL has no id can be listed but not
+// retrieved by id.
+func newMemoryBackend[T Resource](resources []T) *memoryBackend[T] {
+	byId := make(map[int]T, len(resources))
+	for _, resource := range resources {
+		if id, err := resourceId(resource.GetUrl()); err == nil {
+			byId[id] = resource
+		}
+	}
+	return &memoryBackend[T]{
+		resources: resources,
+		byId:      byId,
+	}
+}
+
+// MemoryBackends returns the backends that serve the resources in the given
+// dataset from memory.
+func MemoryBackends(dataset Dataset) Backends {
+	return Backends{
+		People:    newMemoryBackend(dataset.People),
+		Planets:   newMemoryBackend(dataset.Planets),
+		Films:     newMemoryBackend(dataset.Films),
+		Species:   newMemoryBackend(dataset.Species),
+		Vehicles:  newMemoryBackend(dataset.Vehicles),
+		Starships: newMemoryBackend(dataset.Starships),
+	}
+}
+
+// List returns the page with the given number of the resources.
+func (b *memoryBackend[T]) List(_ context.Context, pageNumber int) (SwapiResponse[T], error) {
+	return b.page(b.resources, pageNumber), nil
+}
+
+// Search returns the page with the given number of the resources whose name
+// contains search, ignoring the case, or whose model does for the vehicles and
+// the starships.
+func (b *memoryBackend[T]) Search(_ context.Context, search string, pageNumber int) (SwapiResponse[T], error) {
+	return b.page(filterByName(b.resources, utils.FoldCase(search)), pageNumber), nil
+}
+
+// Get returns the resource with the given id.
+func (b *memoryBackend[T]) Get(_ context.Context, id int) (resource T, err error) {
+	resource, ok := b.byId[id]
+	if !ok {
+		return resource, ErrNotFound
+	}
+	return resource, nil
+}
+
+// page returns the page with the given number of the given resources, with
+// the SWAPI fixed page size. The results are copies, so the callers can modify
+// them. If the page doesn't exist, page returns an empty page with a zero
+// count, as the SWAPI HTTP API does.
+func (b *memoryBackend[T]) page(resources []T, pageNumber int) SwapiResponse[T] {
+	results := paginate(resources, pageNumber, swapiPageSize)
+	if len(results) == 0 {
+		return SwapiResponse[T]{
+			Count:   0,
+			Results: []T{},
+		}
+	}
+	return SwapiResponse[T]{
+		Count:   len(resources),
+		Results: append([]T{}, results...),
+	}
+}
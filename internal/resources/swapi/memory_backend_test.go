@@ -0,0 +1,161 @@
+package swapi
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	internalRequest "github.com/pegondo/starwars-service/internal/request"
+	"github.com/stretchr/testify/require"
+)
+
+// testDataset returns a dataset with the given number of people, with
+// consecutive ids from one, and a planet all of them live in.
+func testDataset(numPeople int) Dataset {
+	planetUrl := "https://swapi.dev/api/planets/1/"
+	people := make([]Person, 0, numPeople)
+	residents := make([]string, 0, numPeople)
+	for i := 1; i <= numPeople; i++ {
+		personUrl := fmt.Sprintf("https://swapi.dev/api/people/%d/", i)
+		people = append(people, Person{
+			Name:      fmt.Sprintf("Person %d", i),
+			Homeworld: planetUrl,
+			Url:       personUrl,
+		})
+		residents = append(residents, personUrl)
+	}
+	return Dataset{
+		People: people,
+		Planets: []Planet{
+			{Name: "Tatooine", Residents: residents, Url: planetUrl},
+		},
+	}
+}
+
+func TestMemoryBackend(t *testing.T) {
+	backend := newMemoryBackend(testDataset(25).People)
+	ctx := context.Background()
+
+	page, err := backend.List(ctx, 3)
+	require.NoError(t, err)
+	require.Equal(t, 25, page.Count)
+	require.Len(t, page.Results, 5)
+	require.Equal(t, "Person 21", page.Results[0].Name)
+
+	page, err = backend.List(ctx, 4)
+	require.NoError(t, err)
+	require.Zero(t, page.Count)
+	require.Empty(t, page.Results)
+
+	page, err = backend.Search(ctx, "PERSON 1", 1)
+	require.NoError(t, err)
+	// Person 1 and Person 10 to Person 19.
+	require.Equal(t, 11, page.Count)
+	require.Len(t, page.Results, swapiPageSize)
+
+	person, err := backend.Get(ctx, 7)
+	require.NoError(t, err)
+	require.Equal(t, "Person 7", person.Name)
+
+	_, err = backend.Get(ctx, 26)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMemoryBackend_SearchModel(t *testing.T) {
+	backend := newMemoryBackend([]Starship{
+		{Name: "Millennium Falcon", Model: "YT-1300 light freighter", Url: "https://swapi.dev/api/starships/10/"},
+		{Name: "X-wing", Model: "T-65 X-wing", Url: "https://swapi.dev/api/starships/12/"},
+		{Name: "Death Star", Model: "DS-1 Orbital Battle Station", Url: "https://swapi.dev/api/starships/9/"},
+	})
+	ctx := context.Background()
+
+	testCases := []struct {
+		name   string
+		search string
+		names  []string
+	}{
+		{
+			name:   "name",
+			search: "falcon",
+			names:  []string{"Millennium Falcon"},
+		},
+		{
+			name:   "model",
+			search: "freighter",
+			names:  []string{"Millennium Falcon"},
+		},
+		{
+			name:   "name_and_model",
+			search: "x-wing",
+			names:  []string{"X-wing"},
+		},
+		{
+			name:   "no_match",
+			search: "tie",
+			names:  []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			page, err := backend.Search(ctx, tc.search, 1)
+			require.NoError(t, err)
+			require.Equal(t, len(tc.names), page.Count)
+			names := []string{}
+			for _, starship := range page.Results {
+				names = append(names, starship.Name)
+			}
+			require.Equal(t, tc.names, names)
+		})
+	}
+}
+
+func TestMemoryBackends_Retrieve(t *testing.T) {
+	ctx := WithBackends(context.Background(), MemoryBackends(testDataset(25)))
+
+	people, err := RetrievePeople(ctx, internalRequest.RequestParams{
+		Page:     2,
+		PageSize: 15,
+		Sort: []internalRequest.SortCriteria{
+			{
+				Field: internalRequest.NameSortField,
+				Order: internalRequest.DescendingOrder,
+			},
+		},
+		Expand: []string{homeworldLink},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 25, people.Count)
+	require.Len(t, people.Results, 10)
+	require.Equal(t, "Person 18", people.Results[0].Name)
+	require.Equal(t, "Tatooine", people.Results[0].Expanded[homeworldLink].(Planet).Name)
+
+	residents, err := RetrievePlanetResidents(ctx, 1, internalRequest.RequestParams{
+		Page:     1,
+		PageSize: 3,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 25, residents.Count)
+	require.Len(t, residents.Results, 3)
+
+	_, err = RetrievePlanetById(ctx, 2, nil)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLoadDataset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dataset.json")
+	err := os.WriteFile(path, []byte(`{"people":[{"name":"Luke Skywalker"}],"planets":[{"name":"Tatooine"}]}`), 0o644)
+	require.NoError(t, err)
+
+	dataset, err := LoadDataset(path)
+	require.NoError(t, err)
+	require.Equal(t, Dataset{
+		People:  []Person{{Name: "Luke Skywalker"}},
+		Planets: []Planet{{Name: "Tatooine"}},
+	}, dataset)
+
+	_, err = LoadDataset(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
@@ -0,0 +1,319 @@
+package swapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/pegondo/starwars-service/internal/store"
+	"github.com/rs/zerolog/log"
+)
+
+// mirror keeps a durable copy of every SWAPI collection in a store on disk.
+// The copy is served from memory and refreshed in the background, so serving
+// the resources never requests SWAPI and survives its outages and the restarts
+// of the service.
+type mirror struct {
+	store *store.Store
+	// upstream are the backends the collections are synced from.
+	upstream Backends
+	// served are the backends that serve the mirrored dataset.
+	served atomic.Pointer[Backends]
+}
+
+// newMirror creates and returns a mirror of the given upstream backends in
+// the given store, which serves no resources until its dataset is set.
+func newMirror(s *store.Store, upstream Backends) *mirror {
+	m := &mirror{
+		store:    s,
+		upstream: upstream,
+	}
+	m.serve(Dataset{})
+	return m
+}
+
+// serve makes the mirror serve the given dataset.
+func (m *mirror) serve(dataset Dataset) {
+	served := MemoryBackends(dataset)
+	m.served.Store(&served)
+}
+
+// backends returns the backends that serve the resources of the mirror, which
+// are always the ones of its latest dataset.
+func (m *mirror) backends() Backends {
+	return Backends{
+		People:    mirroredBackend[Person]{mirror: m},
+		Planets:   mirroredBackend[Planet]{mirror: m},
+		Films:     mirroredBackend[Film]{mirror: m},
+		Species:   mirroredBackend[Species]{mirror: m},
+		Vehicles:  mirroredBackend[Vehicle]{mirror: m},
+		Starships: mirroredBackend[Starship]{mirror: m},
+	}
+}
+
+// mirroredBackend is the Backend that serves the resources of type T of the
+// dataset a mirror serves at the time.
+type mirroredBackend[T Resource] struct {
+	mirror *mirror
+}
+
+// List returns the page with the given number of the mirrored resources.
+func (b mirroredBackend[T]) List(ctx context.Context, pageNumber int) (SwapiResponse[T], error) {
+	return b.current().List(ctx, pageNumber)
+}
+
+// Search returns the page with the given number of the mirrored resources
+// whose name, or model, contains search, ignoring the case.
+func (b mirroredBackend[T]) Search(ctx context.Context, search string, pageNumber int) (SwapiResponse[T], error) {
+	return b.current().Search(ctx, search, pageNumber)
+}
+
+// Get returns the mirrored resource with the given id.
+func (b mirroredBackend[T]) Get(ctx context.Context, id int) (T, error) {
+	return b.current().Get(ctx, id)
+}
+
+// current returns the backend of the latest dataset of the mirror.
+func (b mirroredBackend[T]) current() Backend[T] {
+	return backendOf[T](*b.mirror.served.Load())
+}
+
+// load returns the dataset stored in the mirror.
+func (m *mirror) load() (dataset Dataset, err error) {
+	if dataset.People, err = loadCollection[Person](m.store, peopleEndpoint); err != nil {
+		return dataset, err
+	}
+	if dataset.Planets, err = loadCollection[Planet](m.store, planetsEndpoint); err != nil {
+		return dataset, err
+	}
+	if dataset.Films, err = loadCollection[Film](m.store, filmsEndpoint); err != nil {
+		return dataset, err
+	}
+	if dataset.Species, err = loadCollection[Species](m.store, speciesEndpoint); err != nil {
+		return dataset, err
+	}
+	if dataset.Vehicles, err = loadCollection[Vehicle](m.store, vehiclesEndpoint); err != nil {
+		return dataset, err
+	}
+	if dataset.Starships, err = loadCollection[Starship](m.store, starshipsEndpoint); err != nil {
+		return dataset, err
+	}
+	return dataset, nil
+}
+
+// sync updates the mirror with the resources in the upstream backends and
+// returns the updated dataset and the number of resources that were stored or
+// deleted. The upstream resources are requested skipping the response cache,
+// so each sync gets their latest version.
+func (m *mirror) sync(ctx context.Context) (dataset Dataset, changes int, err error) {
+	ctx = withoutCache(ctx)
+	var n int
+	if dataset.People, n, err = syncCollection(ctx, m.store, m.upstream.People, peopleEndpoint); err != nil {
+		return dataset, changes, err
+	}
+	changes += n
+	if dataset.Planets, n, err = syncCollection(ctx, m.store, m.upstream.Planets, planetsEndpoint); err != nil {
+		return dataset, changes, err
+	}
+	changes += n
+	if dataset.Films, n, err = syncCollection(ctx, m.store, m.upstream.Films, filmsEndpoint); err != nil {
+		return dataset, changes, err
+	}
+	changes += n
+	if dataset.Species, n, err = syncCollection(ctx, m.store, m.upstream.Species, speciesEndpoint); err != nil {
+		return dataset, changes, err
+	}
+	changes += n
+	if dataset.Vehicles, n, err = syncCollection(ctx, m.store, m.upstream.Vehicles, vehiclesEndpoint); err != nil {
+		return dataset, changes, err
+	}
+	changes += n
+	if dataset.Starships, n, err = syncCollection(ctx, m.store, m.upstream.Starships, starshipsEndpoint); err != nil {
+		return dataset, changes, err
+	}
+	changes += n
+	return dataset, changes, nil
+}
+
+// refresh syncs the mirror and serves the updated dataset. If the sync fails,
+// the previous dataset is still served.
+func (m *mirror) refresh(ctx context.Context) error {
+	dataset, changes, err := m.sync(ctx)
+	if err != nil {
+		return fmt.Errorf("error while syncing the SWAPI mirror :: %w", err)
+	}
+	m.serve(dataset)
+	log.Info().Msgf("SWAPI mirror synced with %d changes", changes)
+	return nil
+}
+
+// populate refreshes the mirror until a refresh succeeds or ctx is done,
+// waiting retryInterval between the attempts. It returns whether the mirror
+// was refreshed.
+func (m *mirror) populate(ctx context.Context, retryInterval time.Duration) bool {
+	for {
+		err := m.refresh(ctx)
+		if err == nil {
+			return true
+		}
+		log.Error().Msgf("%v, retrying in %s", err, retryInterval)
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// run populates the mirror if it's empty and then refreshes it every interval
+// until ctx is done. If interval isn't greater than zero, the mirror is only
+// populated.
+func (m *mirror) run(ctx context.Context, empty bool, interval time.Duration) {
+	if empty && !m.populate(ctx, mirrorRetryInterval) {
+		return
+	}
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.refresh(ctx); err != nil {
+				log.Error().Msg(err.Error())
+			}
+		}
+	}
+}
+
+// initMirror returns the backends that serve the resources from the mirror in
+// the given directory of the given upstream backends, which is refreshed in
+// the background every interval. If interval isn't greater than zero, the
+// mirror isn't refreshed. If the mirror is empty, it serves no resources until
+// it's populated from upstream in the background, so an upstream outage
+// doesn't prevent the service from starting.
+func initMirror(ctx context.Context, dir string, upstream Backends, interval time.Duration) (Backends, error) {
+	s, err := store.Open(dir)
+	if err != nil {
+		return Backends{}, err
+	}
+	m := newMirror(s, upstream)
+	dataset, err := m.load()
+	if err != nil {
+		return Backends{}, fmt.Errorf("error while loading the SWAPI mirror :: %w", err)
+	}
+	m.serve(dataset)
+	go m.run(ctx, dataset.empty(), interval)
+	return m.backends(), nil
+}
+
+// loadCollection returns the resources of the given SWAPI endpoint stored in
+// the given store, sorted by id as SWAPI serves them.
+func loadCollection[T Resource](s *store.Store, endpoint string) ([]T, error) {
+	stored, err := storedCollection[T](s, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return sortedById(stored), nil
+}
+
+// storedCollection returns the resources of the given SWAPI endpoint stored in
+// the given store by id.
+func storedCollection[T Resource](s *store.Store, endpoint string) (map[int]T, error) {
+	values, err := s.All(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	resources := make(map[int]T, len(values))
+	for key, value := range values {
+		id, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s/%s in the SWAPI mirror", endpoint, key)
+		}
+		var resource T
+		if err = json.Unmarshal(value, &resource); err != nil {
+			return nil, fmt.Errorf("error while parsing the key %s/%s of the SWAPI mirror :: %v", endpoint, key, err)
+		}
+		resources[id] = resource
+	}
+	return resources, nil
+}
+
+// syncCollection updates the resources of the given SWAPI endpoint in the
+// given store with the ones listed by the given backend. Only the resources
+// whose edited timestamp changed are stored again, and the ones that no longer
+// exist are deleted. syncCollection returns the updated resources, sorted by
+// id, and the number of resources stored or deleted.
+func syncCollection[T Resource](
+	ctx context.Context,
+	s *store.Store,
+	backend Backend[T],
+	endpoint string,
+) (
+	resources []T,
+	changes int,
+	err error,
+) {
+	stored, err := storedCollection[T](s, endpoint)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := crawlAll(ctx, backend, endpoint, "")
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(resp.Results) == 0 && len(stored) > 0 {
+		// An empty collection is more likely an upstream failure than every
+		// resource being removed, so keep the stored ones.
+		return sortedById(stored), 0, nil
+	}
+
+	upstream := make(map[int]T, len(resp.Results))
+	for _, resource := range resp.Results {
+		id, err := resourceId(resource.GetUrl())
+		if err != nil {
+			// The resources without an id can't be retrieved, so they aren't
+			// mirrored.
+			continue
+		}
+		upstream[id] = resource
+		if previous, ok := stored[id]; ok && previous.GetEdited().Equal(resource.GetEdited()) {
+			continue
+		}
+		value, err := json.Marshal(resource)
+		if err != nil {
+			return nil, changes, fmt.Errorf("error while encoding the key %s/%d of the SWAPI mirror :: %v", endpoint, id, err)
+		}
+		if err = s.Put(endpoint, strconv.Itoa(id), value); err != nil {
+			return nil, changes, err
+		}
+		changes++
+	}
+	for id := range stored {
+		if _, ok := upstream[id]; ok {
+			continue
+		}
+		if err = s.Delete(endpoint, strconv.Itoa(id)); err != nil {
+			return nil, changes, err
+		}
+		changes++
+	}
+	return sortedById(upstream), changes, nil
+}
+
+// sortedById returns the given resources sorted by id.
+func sortedById[T Resource](resources map[int]T) []T {
+	sorted := make([]T, 0, len(resources))
+	for _, id := range slices.Sorted(maps.Keys(resources)) {
+		sorted = append(sorted, resources[id])
+	}
+	return sorted
+}
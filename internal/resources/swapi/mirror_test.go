@@ -0,0 +1,169 @@
+package swapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pegondo/starwars-service/internal/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirror_Sync(t *testing.T) {
+	dir := t.TempDir()
+	s, err := store.Open(dir)
+	require.NoError(t, err)
+	dataset := testDataset(25)
+	m := newMirror(s, MemoryBackends(dataset))
+	ctx := context.Background()
+
+	synced, changes, err := m.sync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 26, changes)
+	require.Equal(t, dataset.People, synced.People)
+	require.Equal(t, dataset.Planets, synced.Planets)
+
+	// Nothing changed upstream.
+	_, changes, err = m.sync(ctx)
+	require.NoError(t, err)
+	require.Zero(t, changes)
+
+	// A person was edited and another one was removed.
+	dataset.People[0].Name = "Edited person"
+	dataset.People[0].Edited = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	dataset.People = dataset.People[:24]
+	m.upstream = MemoryBackends(dataset)
+	synced, changes, err = m.sync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, changes)
+	require.Equal(t, dataset.People, synced.People)
+
+	// The mirror survives a restart.
+	reopened, err := store.Open(dir)
+	require.NoError(t, err)
+	loaded, err := newMirror(reopened, MemoryBackends(Dataset{})).load()
+	require.NoError(t, err)
+	require.Equal(t, dataset.People, loaded.People)
+	require.Equal(t, dataset.Planets, loaded.Planets)
+}
+
+func TestMirror_SyncEmptyUpstream(t *testing.T) {
+	s, err := store.Open(t.TempDir())
+	require.NoError(t, err)
+	dataset := testDataset(3)
+	m := newMirror(s, MemoryBackends(dataset))
+	ctx := context.Background()
+	_, _, err = m.sync(ctx)
+	require.NoError(t, err)
+
+	m.upstream = MemoryBackends(Dataset{})
+	synced, changes, err := m.sync(ctx)
+	require.NoError(t, err)
+	require.Zero(t, changes)
+	require.Equal(t, dataset.People, synced.People)
+}
+
+func TestMirror_Refresh(t *testing.T) {
+	s, err := store.Open(t.TempDir())
+	require.NoError(t, err)
+	m := newMirror(s, MemoryBackends(testDataset(12)))
+	ctx := WithBackends(context.Background(), m.backends())
+
+	_, err = RetrievePersonById(ctx, 12, nil)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, m.refresh(context.Background()))
+
+	people, err := RetrievePersonById(ctx, 12, nil)
+	require.NoError(t, err)
+	require.Equal(t, "Person 12", people.Name)
+}
+
+func TestMirror_SyncSkipsCache(t *testing.T) {
+	var version atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/people" {
+			fmt.Fprint(w, `{"count":0,"next":null,"results":[]}`)
+			return
+		}
+		v := version.Load()
+		fmt.Fprintf(w, `{"count":1,"next":null,"results":[{"name":"Person v%d","edited":"2025-01-0%dT00:00:00Z","url":"https://swapi.dev/api/people/1/"}]}`, v, v)
+	}))
+	defer server.Close()
+	useServer(t, server.URL)
+
+	s, err := store.Open(t.TempDir())
+	require.NoError(t, err)
+	m := newMirror(s, HttpBackends())
+	ctx := context.Background()
+
+	version.Store(1)
+	synced, changes, err := m.sync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, changes)
+	require.Equal(t, "Person v1", synced.People[0].Name)
+
+	// The person changed upstream, so the next sync must get it instead of
+	// the cached response.
+	version.Store(2)
+	synced, changes, err = m.sync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, changes)
+	require.Equal(t, "Person v2", synced.People[0].Name)
+}
+
+// failingBackend is a Backend whose requests always fail, which counts them.
+type failingBackend[T Resource] struct {
+	numRequests *atomic.Int32
+}
+
+func (b failingBackend[T]) List(context.Context, int) (SwapiResponse[T], error) {
+	b.numRequests.Add(1)
+	return SwapiResponse[T]{}, ErrUpstreamUnavailable
+}
+
+func (b failingBackend[T]) Search(context.Context, string, int) (SwapiResponse[T], error) {
+	b.numRequests.Add(1)
+	return SwapiResponse[T]{}, ErrUpstreamUnavailable
+}
+
+func (b failingBackend[T]) Get(context.Context, int) (resource T, err error) {
+	b.numRequests.Add(1)
+	return resource, ErrUpstreamUnavailable
+}
+
+func TestInitMirror_UpstreamDown(t *testing.T) {
+	var numRequests atomic.Int32
+	upstream := Backends{People: failingBackend[Person]{numRequests: &numRequests}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	backends, err := initMirror(ctx, t.TempDir(), upstream, 0)
+	// The upstream being down must not prevent the mirror from starting
+	// empty.
+	require.NoError(t, err)
+	people, err := backends.People.List(ctx, 1)
+	require.NoError(t, err)
+	require.Zero(t, people.Count)
+	// The mirror tries to populate itself in the background.
+	require.Eventually(t, func() bool {
+		return numRequests.Load() > 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestMirror_PopulateRetries(t *testing.T) {
+	var numRequests atomic.Int32
+	s, err := store.Open(t.TempDir())
+	require.NoError(t, err)
+	m := newMirror(s, Backends{People: failingBackend[Person]{numRequests: &numRequests}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.False(t, m.populate(ctx, time.Millisecond))
+	require.Greater(t, numRequests.Load(), int32(1))
+}
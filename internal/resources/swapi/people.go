@@ -1,6 +1,7 @@
 package swapi
 
 import (
+	"context"
 	"time"
 
 	internalRequest "github.com/pegondo/starwars-service/internal/request"
@@ -44,6 +45,18 @@ type Person struct {
 	Mass string `json:"mass"`
 	// SkinColor is the color of the person.
 	SkinColor string `json:"skin_color"`
+	// Homeworld is the URL of the planet this person was born on or
+	// inhabits.
+	Homeworld string `json:"homeworld"`
+	// Films is the list of URLs of the films this person has been in.
+	Films []string `json:"films"`
+	// Species is the list of URLs of the species this person belongs to.
+	Species []string `json:"species"`
+	// Vehicles is the list of URLs of the vehicles this person has piloted.
+	Vehicles []string `json:"vehicles"`
+	// Starships is the list of URLs of the starships this person has
+	// piloted.
+	Starships []string `json:"starships"`
 	// Url is the URL to the resource of this person.
 	Url string `json:"url"`
 	// Created is the time when the resource of this person was created.
@@ -51,6 +64,12 @@ type Person struct {
 	// Edited is the time when the resource of this person was edited for the
 	// last time.
 	Edited time.Time `json:"edited"`
+	// Expanded contains the resources linked by the person that were
+	// requested to be expanded.
+	Expanded Expansion `json:"expanded,omitempty"`
+	// Score is the relevance of the person for the full-text search query. It's
+	// nil if the person wasn't found with a full-text search.
+	Score *float64 `json:"score,omitempty"`
 }
 
 // GetName returns the person name.
@@ -63,20 +82,143 @@ func (p Person) GetCreated() time.Time {
 	return p.Created
 }
 
+// GetUrl returns the URL of the person's resource in SWAPI.
+func (p Person) GetUrl() string {
+	return p.Url
+}
+
+// GetEdited returns the last time the person's resource was edited in SWAPI.
+func (p Person) GetEdited() time.Time {
+	return p.Edited
+}
+
+// fields returns the scalar fields of the person that can be filtered by.
+func (p Person) fields() fields {
+	return fields{
+		"name":       p.Name,
+		"birth_year": p.BirthYear,
+		"eye_color":  optionalField(p.EyeColor),
+		"gender":     optionalField(p.Gender),
+		"hair_color": optionalField(p.HairColor),
+		"height":     p.Height,
+		"mass":       p.Mass,
+		"skin_color": p.SkinColor,
+	}
+}
+
+// links returns the link fields of the person.
+func (p Person) links() links {
+	return links{
+		homeworldLink: {p.Homeworld},
+		filmsLink:     p.Films,
+		speciesLink:   p.Species,
+		vehiclesLink:  p.Vehicles,
+		starshipsLink: p.Starships,
+	}
+}
+
+// setExpanded sets the expanded resources of the person.
+func (p *Person) setExpanded(expanded Expansion) {
+	p.Expanded = expanded
+}
+
+// setScore sets the relevance of the person for the full-text search query.
+func (p *Person) setScore(score float64) {
+	p.Score = &score
+}
+
+// rewriteUrls replaces the URLs of the person's resource, its links and its
+// expanded resources with the result of rewrite.
+func (p *Person) rewriteUrls(rewrite func(string) string) {
+	p.Url = rewrite(p.Url)
+	p.Homeworld = rewrite(p.Homeworld)
+	p.Films = rewriteAll(p.Films, rewrite)
+	p.Species = rewriteAll(p.Species, rewrite)
+	p.Vehicles = rewriteAll(p.Vehicles, rewrite)
+	p.Starships = rewriteAll(p.Starships, rewrite)
+	p.Expanded = p.Expanded.rewriteUrls(rewrite)
+}
+
 // RetrievePeople requests the SWAPI for people. The SWAPI doesn't support
 // pagination with variable page sizes, but this function does the maths and
 // requests the endpoint various times if needed to return the data for the
 // given page and page size. If params.Search is not "", the people returned
-// will contain the value of search in their name. If params.SortCriteria isn't
-// nil, the people will be ordered with the defined criteria.
+// will contain the value of search in their name. If params.Sort isn't empty,
+// the people will be ordered with the defined criteria. The link fields in
+// params.Expand will be expanded.
 func RetrievePeople(
+	ctx context.Context,
 	params internalRequest.RequestParams,
 ) (
 	peopleResp SwapiResponse[Person],
 	err error,
 ) {
-	if params.SortCriteria != nil {
-		return retrieveAllAndSort[Person](peopleEndpoint, params)
-	}
-	return retrievePage[Person](peopleEndpoint, params)
+	return retrieveCollection[Person](ctx, peopleEndpoint, params)
+}
+
+// RetrievePersonById requests the SWAPI for the person with the given id. If
+// there is no person with that id, RetrievePersonById returns ErrNotFound. The
+// given link fields will be expanded.
+func RetrievePersonById(ctx context.Context, id int, expandFields []string) (person Person, err error) {
+	return retrieveById[Person](ctx, peopleEndpoint, id, expandFields)
+}
+
+// RetrievePersonFilms requests the SWAPI for the films of the person with the
+// given id. The search, sorting, pagination and expansion in params are applied
+// to the films as in the top-level collections. If there is no person with that
+// id, RetrievePersonFilms returns ErrNotFound.
+func RetrievePersonFilms(
+	ctx context.Context,
+	id int,
+	params internalRequest.RequestParams,
+) (
+	filmsResp SwapiResponse[Film],
+	err error,
+) {
+	return retrieveLinkedCollection[Person, Film](ctx, peopleEndpoint, id, filmsLink, params)
+}
+
+// RetrievePersonSpecies requests the SWAPI for the species of the person with
+// the given id. The search, sorting, pagination and expansion in params are
+// applied to the species as in the top-level collections. If there is no person
+// with that id, RetrievePersonSpecies returns ErrNotFound.
+func RetrievePersonSpecies(
+	ctx context.Context,
+	id int,
+	params internalRequest.RequestParams,
+) (
+	speciesResp SwapiResponse[Species],
+	err error,
+) {
+	return retrieveLinkedCollection[Person, Species](ctx, peopleEndpoint, id, speciesLink, params)
+}
+
+// RetrievePersonVehicles requests the SWAPI for the vehicles of the person with
+// the given id. The search, sorting, pagination and expansion in params are
+// applied to the vehicles as in the top-level collections. If there is no
+// person with that id, RetrievePersonVehicles returns ErrNotFound.
+func RetrievePersonVehicles(
+	ctx context.Context,
+	id int,
+	params internalRequest.RequestParams,
+) (
+	vehiclesResp SwapiResponse[Vehicle],
+	err error,
+) {
+	return retrieveLinkedCollection[Person, Vehicle](ctx, peopleEndpoint, id, vehiclesLink, params)
+}
+
+// RetrievePersonStarships requests the SWAPI for the starships of the person
+// with the given id. The search, sorting, pagination and expansion in params
+// are applied to the starships as in the top-level collections. If there is no
+// person with that id, RetrievePersonStarships returns ErrNotFound.
+func RetrievePersonStarships(
+	ctx context.Context,
+	id int,
+	params internalRequest.RequestParams,
+) (
+	starshipsResp SwapiResponse[Starship],
+	err error,
+) {
+	return retrieveLinkedCollection[Person, Starship](ctx, peopleEndpoint, id, starshipsLink, params)
 }
@@ -1,6 +1,7 @@
 package swapi
 
 import (
+	"context"
 	"time"
 
 	internalRequest "github.com/pegondo/starwars-service/internal/request"
@@ -36,6 +37,10 @@ type Planet struct {
 	// SurfaceWater is the percentage of the planet surface that is naturally
 	// occurring water or bodies of water.
 	SurfaceWater string `json:"surface_water"`
+	// Residents is the list of URLs of the people that live on this planet.
+	Residents []string `json:"residents"`
+	// Films is the list of URLs of the films this planet has appeared in.
+	Films []string `json:"films"`
 	// Url is the URL to the resource of this planet.
 	Url string `json:"url"`
 	// Created is the time when the resource of this planet was created.
@@ -43,6 +48,12 @@ type Planet struct {
 	// Edited is the time when the resource of this planet was edited for the
 	// last time.
 	Edited time.Time `json:"edited"`
+	// Expanded contains the resources linked by the planet that were
+	// requested to be expanded.
+	Expanded Expansion `json:"expanded,omitempty"`
+	// Score is the relevance of the planet for the full-text search query. It's
+	// nil if the planet wasn't found with a full-text search.
+	Score *float64 `json:"score,omitempty"`
 }
 
 // GetName returns the planet name.
@@ -55,20 +66,108 @@ func (p Planet) GetCreated() time.Time {
 	return p.Created
 }
 
+// GetUrl returns the URL of the planet's resource in SWAPI.
+func (p Planet) GetUrl() string {
+	return p.Url
+}
+
+// GetEdited returns the last time the planet's resource was edited in SWAPI.
+func (p Planet) GetEdited() time.Time {
+	return p.Edited
+}
+
+// fields returns the scalar fields of the planet that can be filtered by.
+func (p Planet) fields() fields {
+	return fields{
+		"name":            p.Name,
+		"diameter":        p.Diameter,
+		"rotation_period": p.RotationPeriod,
+		"orbital_period":  p.OrbitalPeriod,
+		"gravity":         p.Gravity,
+		"population":      p.Population,
+		"climate":         p.Climate,
+		"terrain":         p.Terrain,
+		"surface_water":   p.SurfaceWater,
+	}
+}
+
+// links returns the link fields of the planet.
+func (p Planet) links() links {
+	return links{
+		residentsLink: p.Residents,
+		filmsLink:     p.Films,
+	}
+}
+
+// setExpanded sets the expanded resources of the planet.
+func (p *Planet) setExpanded(expanded Expansion) {
+	p.Expanded = expanded
+}
+
+// setScore sets the relevance of the planet for the full-text search query.
+func (p *Planet) setScore(score float64) {
+	p.Score = &score
+}
+
+// rewriteUrls replaces the URLs of the planet's resource, its links and its
+// expanded resources with the result of rewrite.
+func (p *Planet) rewriteUrls(rewrite func(string) string) {
+	p.Url = rewrite(p.Url)
+	p.Residents = rewriteAll(p.Residents, rewrite)
+	p.Films = rewriteAll(p.Films, rewrite)
+	p.Expanded = p.Expanded.rewriteUrls(rewrite)
+}
+
 // RetrievePlanets requests the SWAPI for planets. The SWAPI doesn't support
 // pagination with variable page sizes, but this function does the maths and
 // requests the endpoint various times if needed to return the data for the
 // given page and page size. If params.Search is not "", the planets returned
-// will contain the value of search in their name. If params.SortCriteria isn't
-// nil, the planets will be ordered with the defined criteria.
+// will contain the value of search in their name. If params.Sort isn't empty,
+// the planets will be ordered with the defined criteria. The link fields in
+// params.Expand will be expanded.
 func RetrievePlanets(
+	ctx context.Context,
 	params internalRequest.RequestParams,
 ) (
 	planetsResp SwapiResponse[Planet],
 	err error,
 ) {
-	if params.SortCriteria != nil {
-		return retrieveAllAndSort[Planet](planetsEndpoint, params)
-	}
-	return retrievePage[Planet](planetsEndpoint, params)
+	return retrieveCollection[Planet](ctx, planetsEndpoint, params)
+}
+
+// RetrievePlanetById requests the SWAPI for the planet with the given id. If
+// there is no planet with that id, RetrievePlanetById returns ErrNotFound. The
+// given link fields will be expanded.
+func RetrievePlanetById(ctx context.Context, id int, expandFields []string) (planet Planet, err error) {
+	return retrieveById[Planet](ctx, planetsEndpoint, id, expandFields)
+}
+
+// RetrievePlanetResidents requests the SWAPI for the residents of the planet
+// with the given id. The search, sorting, pagination and expansion in params
+// are applied to the residents as in the top-level collections. If there is no
+// planet with that id, RetrievePlanetResidents returns ErrNotFound.
+func RetrievePlanetResidents(
+	ctx context.Context,
+	id int,
+	params internalRequest.RequestParams,
+) (
+	residentsResp SwapiResponse[Person],
+	err error,
+) {
+	return retrieveLinkedCollection[Planet, Person](ctx, planetsEndpoint, id, residentsLink, params)
+}
+
+// RetrievePlanetFilms requests the SWAPI for the films of the planet with the
+// given id. The search, sorting, pagination and expansion in params are applied
+// to the films as in the top-level collections. If there is no planet with that
+// id, RetrievePlanetFilms returns ErrNotFound.
+func RetrievePlanetFilms(
+	ctx context.Context,
+	id int,
+	params internalRequest.RequestParams,
+) (
+	filmsResp SwapiResponse[Film],
+	err error,
+) {
+	return retrieveLinkedCollection[Planet, Film](ctx, planetsEndpoint, id, filmsLink, params)
 }
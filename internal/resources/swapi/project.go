@@ -0,0 +1,119 @@
+package swapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// keptFields are the fields the service adds to the resources, which are kept
+// in the projections when they are present, as they are only present if they
+// were requested with the expand or q query parameters. They can't be
+// requested as fields.
+var keptFields = map[string]bool{
+	"expanded": true,
+	"score":    true,
+}
+
+// Projection is a resource trimmed to some of its fields. It's marshaled to a
+// JSON object with those fields in the same order as in the resource.
+type Projection struct {
+	// names are the names of the fields, in order.
+	names []string
+	// values are the JSON values of the fields by name.
+	values map[string]json.RawMessage
+}
+
+// MarshalJSON marshals the projection to a JSON object with its fields in
+// order.
+func (p Projection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, name := range p.names {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(p.values[name])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// resourceFields returns the names of the fields of the resources of type T as
+// they are named in the responses, in order.
+func resourceFields[T Resource]() []string {
+	t := reflect.TypeFor[T]()
+	names := make([]string, 0, t.NumField())
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ValidateFields validates that all the given fields are fields of the
+// resource T, as they are named in the responses ignoring the case, e.g.
+// "MGLT" or "mglt" for the starships. The fields the service adds, such as
+// "score", aren't fields of the resource. If any of them isn't, ValidateFields
+// returns ErrInvalidField.
+func ValidateFields[T Resource](fields []string) error {
+	names := resourceFields[T]()
+	for _, field := range fields {
+		valid := slices.ContainsFunc(names, func(name string) bool {
+			return !keptFields[name] && strings.EqualFold(name, field)
+		})
+		if !valid {
+			return ErrInvalidField
+		}
+	}
+	return nil
+}
+
+// isRequested returns whether the field with the given name is in the given
+// requested fields, ignoring the case.
+func isRequested(fields []string, name string) bool {
+	return slices.ContainsFunc(fields, func(field string) bool {
+		return strings.EqualFold(field, name)
+	})
+}
+
+// Project returns the given resources trimmed to the given fields, which must
+// be valid as ValidateFields validates. The expanded resources and the score
+// are kept if they are present, as they are only present when requested.
+func Project[T Resource](resources []T, fields []string) ([]Projection, error) {
+	names := resourceFields[T]()
+	projections := make([]Projection, 0, len(resources))
+	for _, resource := range resources {
+		body, err := json.Marshal(resource)
+		if err != nil {
+			return nil, fmt.Errorf("error while marshaling the resource %s :: %v", resource.GetUrl(), err)
+		}
+		var values map[string]json.RawMessage
+		if err = json.Unmarshal(body, &values); err != nil {
+			return nil, fmt.Errorf("error while unmarshaling the resource %s :: %v", resource.GetUrl(), err)
+		}
+
+		projection := Projection{values: values}
+		for _, name := range names {
+			if _, ok := values[name]; !ok {
+				continue
+			}
+			if keptFields[name] || isRequested(fields, name) {
+				projection.names = append(projection.names, name)
+			}
+		}
+		projections = append(projections, projection)
+	}
+	return projections, nil
+}
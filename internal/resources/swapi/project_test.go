@@ -0,0 +1,121 @@
+package swapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFields(t *testing.T) {
+	testCases := []struct {
+		name   string
+		fields []string
+		err    error
+	}{
+		{
+			name:   "no_fields",
+			fields: nil,
+		},
+		{
+			name:   "valid_fields",
+			fields: []string{"name", "height", "url"},
+		},
+		{
+			name:   "link_field",
+			fields: []string{"films"},
+		},
+		{
+			name:   "added_fields",
+			fields: []string{"expanded", "score"},
+			err:    ErrInvalidField,
+		},
+		{
+			name:   "field_of_another_resource",
+			fields: []string{"name", "climate"},
+			err:    ErrInvalidField,
+		},
+		{
+			name:   "go_field_name",
+			fields: []string{"skincolor"},
+			err:    ErrInvalidField,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorIs(t, ValidateFields[Person](tc.fields), tc.err)
+		})
+	}
+}
+
+func TestValidateFields_Case(t *testing.T) {
+	// The starships name a field in uppercase, which is requested in any case.
+	require.NoError(t, ValidateFields[Starship]([]string{"mglt"}))
+	require.NoError(t, ValidateFields[Starship]([]string{"MGLT"}))
+
+	projections, err := Project([]Starship{{Name: "X-wing", MGLT: "100"}}, []string{"mglt"})
+	require.NoError(t, err)
+	body, err := json.Marshal(projections[0])
+	require.NoError(t, err)
+	require.JSONEq(t, `{"MGLT":"100"}`, string(body))
+}
+
+func TestProject(t *testing.T) {
+	testCases := []struct {
+		name   string
+		person Person
+		fields []string
+		json   string
+	}{
+		{
+			name:   "resource_order",
+			person: Person{Name: "Luke Skywalker", Height: "172", Mass: "77", Url: "https://swapi.dev/api/people/1/"},
+			fields: []string{"url", "name", "height"},
+			json:   `{"name":"Luke Skywalker","height":"172","url":"https://swapi.dev/api/people/1/"}`,
+		},
+		{
+			name:   "nil_and_list_fields",
+			person: Person{Name: "Luke Skywalker", Films: []string{"https://swapi.dev/api/films/1/"}},
+			fields: []string{"eye_color", "films"},
+			json:   `{"eye_color":null,"films":["https://swapi.dev/api/films/1/"]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			projections, err := Project([]Person{tc.person}, tc.fields)
+			require.NoError(t, err)
+			require.Len(t, projections, 1)
+
+			body, err := json.Marshal(projections[0])
+			require.NoError(t, err)
+			require.Equal(t, tc.json, string(body))
+		})
+	}
+}
+
+func TestProject_KeepsAddedFields(t *testing.T) {
+	score := 1.5
+	person := Person{
+		Name:     "Luke Skywalker",
+		Height:   "172",
+		Expanded: Expansion{homeworldLink: Planet{Name: "Tatooine"}},
+		Score:    &score,
+	}
+
+	projections, err := Project([]Person{person}, []string{"name"})
+	require.NoError(t, err)
+	require.Len(t, projections, 1)
+	body, err := json.Marshal(projections[0])
+	require.NoError(t, err)
+
+	var values map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(body, &values))
+	require.Len(t, values, 3)
+	require.JSONEq(t, `"Luke Skywalker"`, string(values["name"]))
+	require.JSONEq(t, `1.5`, string(values["score"]))
+	var expanded map[string]Planet
+	require.NoError(t, json.Unmarshal(values["expanded"], &expanded))
+	require.Equal(t, "Tatooine", expanded[homeworldLink].Name)
+}
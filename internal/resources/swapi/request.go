@@ -1,20 +1,22 @@
 package swapi
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"math"
+	"mime"
 	"net/http"
-	"os"
-	"sort"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/lpernett/godotenv"
-
+	"github.com/pegondo/starwars-service/internal/logger"
 	internalRequest "github.com/pegondo/starwars-service/internal/request"
-	"github.com/pegondo/starwars-service/internal/utils"
 )
 
 // Source: https://swapi.dev/documentation
@@ -23,27 +25,48 @@ const (
 	swapiPageSize = 10
 )
 
-// getSwapiBaseUrl returns the SWAPI base URL.
-func getSwapiBaseUrl() string {
-	godotenv.Load()
-	if swapiBaseUrlEnv, exists := os.LookupEnv("SWAPI_BASE_URL"); exists {
-		return swapiBaseUrlEnv
-	}
-	return "https://swapi.dev/api"
-}
-
-// swapiBaseUrl is the base URL of the SWAPI.
-var swapiBaseUrl = getSwapiBaseUrl()
-
-// ErrInvalidSortField is the error returned when the sort field in SortCriteria
-// is invalid.
-var ErrInvalidSortField = errors.New("invalid sort field")
+var (
+	// ErrInvalidSortField is the error returned when the sort field in
+	// SortCriteria is invalid.
+	ErrInvalidSortField = errors.New("invalid sort field")
+	// ErrNotFound is the error returned when SWAPI doesn't have the requested
+	// resource.
+	ErrNotFound = errors.New("resource not found")
+	// ErrInvalidExpandField is the error returned when a field to expand isn't
+	// a link field of the resource.
+	ErrInvalidExpandField = errors.New("invalid expand field")
+	// ErrInvalidField is the error returned when a field to respond with isn't
+	// a field of the resource.
+	ErrInvalidField = errors.New("invalid field")
+	// ErrInvalidFilterField is the error returned when a field to filter by
+	// isn't a field of the resource.
+	ErrInvalidFilterField = errors.New("invalid filter field")
+	// ErrInvalidFilterOperator is the error returned when a range filter uses a
+	// field that isn't numeric.
+	ErrInvalidFilterOperator = errors.New("invalid filter operator")
+	// ErrUpstreamTimeout is the error returned when SWAPI doesn't respond
+	// before the request deadline.
+	ErrUpstreamTimeout = errors.New("upstream request timed out")
+	// ErrUpstreamUnavailable is the error returned when the circuit breaker is
+	// open and SWAPI isn't requested.
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+	// ErrRateLimited is the error returned when SWAPI rejects the requests
+	// because too many have been performed.
+	ErrRateLimited = errors.New("upstream rate limit exceeded")
+	// ErrUpstreamError is the error returned when SWAPI fails or responds with
+	// something that isn't a valid JSON response.
+	ErrUpstreamError = errors.New("upstream error")
+)
 
 // Resource represents a SWAPI resource the API serves.
 type Resource interface {
-	Person | Planet
+	Person | Planet | Film | Species | Vehicle | Starship
 	GetName() string
 	GetCreated() time.Time
+	GetEdited() time.Time
+	GetUrl() string
+	fields() fields
+	links() links
 }
 
 // SwapiResponse represents the SWAPI response for a resource T.
@@ -54,6 +77,9 @@ type SwapiResponse[T Resource] struct {
 	Next *string `json:"next"`
 	// Results are the paginated elements in the collection.
 	Results []T `json:"results"`
+	// Suggestions are the names similar to the search when no name contains
+	// it, e.g. "Dagobah" for "dagoba". SWAPI doesn't serve them.
+	Suggestions []string `json:"-"`
 }
 
 // indexes represent a pair of min and max indexes.
@@ -68,33 +94,249 @@ type page struct {
 	offset int
 }
 
-// request performs a HTTP request to the given URL returns its response.
-func request[T Resource](url string) (response SwapiResponse[T], err error) {
-	resp, err := http.Get(url)
+// fetchGroup deduplicates the concurrent requests to the same SWAPI URL.
+var fetchGroup flightGroup[string, fetchResult]
+
+// fetchResult is the result of a request to SWAPI.
+type fetchResult struct {
+	body []byte
+	// baseUrl is the base URL of the SWAPI mirror that served the request.
+	baseUrl string
+}
+
+// noCacheKey is the key of the context value that makes the requests to SWAPI
+// performed with the context skip the response cache.
+type noCacheKey struct{}
+
+// withoutCache returns a copy of ctx whose requests to SWAPI skip the response
+// cache, so they get the latest resources. Their responses are still cached
+// for the rest of the requests.
+func withoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+// skipsCache returns whether the requests to SWAPI performed with ctx skip the
+// response cache, see withoutCache.
+func skipsCache(ctx context.Context) bool {
+	skip, _ := ctx.Value(noCacheKey{}).(bool)
+	return skip
+}
+
+// fetch performs a HTTP GET request to the given URL and returns its body. If
+// SWAPI responds with a 404, fetch returns ErrNotFound. The bodies of the
+// successful responses are cached, so fetch only performs the request if the
+// URL isn't in the cache, unless ctx skips the cache, see withoutCache.
+// Concurrent fetches of the same URL share a single request. If ctx is done
+// before the response arrives, fetch returns the context error. The SWAPI
+// mirror that serves the request is recorded in ctx, see ServedBy.
+func fetch(ctx context.Context, url string) (body []byte, err error) {
+	if !skipsCache(ctx) {
+		if body, ok := responseCache.Get(url); ok {
+			return body, nil
+		}
+	}
+
+	result, err, _ := fetchGroup.do(ctx, url, func(ctx context.Context) (fetchResult, error) {
+		return fetchUncached(ctx, url)
+	})
+	recordServedBy(ctx, result.baseUrl)
+	return result.body, err
+}
+
+// fetchUncached performs a HTTP GET request to the given URL, which must start
+// with swapiBaseUrl, and returns its body, storing it in the cache if the
+// request succeeds. The request is sent to the SWAPI mirrors in order, failing
+// over to the next one when a mirror fails. If SWAPI responds with a 404,
+// fetchUncached returns ErrNotFound. If SWAPI doesn't respond within
+// requestTimeout, or all the attempts don't finish within upstreamDeadline,
+// fetchUncached returns ErrUpstreamTimeout. The transient failures, such as
+// connection errors or 503 responses, are retried up to retryMaxAttempts times
+// with an exponential backoff. If the circuit breakers don't allow requesting
+// any mirror, fetchUncached returns ErrUpstreamUnavailable.
+func fetchUncached(ctx context.Context, url string) (result fetchResult, err error) {
+	path, ok := strings.CutPrefix(url, swapiBaseUrl)
+	if !ok {
+		return result, fmt.Errorf("%w :: %s isn't a SWAPI URL", ErrUpstreamError, url)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, upstreamDeadline)
+	defer cancel()
+
+	l := logger.FromContext(ctx)
+	for attempt := 1; ; attempt++ {
+		result.body, result.baseUrl, err = fetchFromUpstreams(ctx, path)
+		var retryErr *retryableError
+		if !errors.As(err, &retryErr) {
+			break
+		}
+		if attempt >= retryMaxAttempts {
+			l.Warn().Msgf("giving up requesting %s after %d attempts :: %v", url, attempt, err)
+			return result, retryErr.err
+		}
+		delay := backoffDelay(attempt, retryErr.retryAfter)
+		l.Warn().Msgf("attempt %d of %d requesting %s failed, retrying in %s :: %v", attempt, retryMaxAttempts, url, delay, err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				l.Warn().Msgf("giving up requesting %s, the deadline of %s was exceeded :: %v", url, upstreamDeadline, err)
+				return result, ErrUpstreamTimeout
+			}
+			return result, ctx.Err()
+		}
+	}
+	if result.baseUrl != "" {
+		l.Info().Msgf("%s served by the SWAPI mirror %s", path, result.baseUrl)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	responseCache.Set(url, result.body)
+	return result, nil
+}
+
+// isUpstreamHealthy reports whether the given error of a request to SWAPI
+// allows to consider it healthy.
+func isUpstreamHealthy(err error) bool {
+	var retryErr *retryableError
+	return !errors.As(err, &retryErr) &&
+		!errors.Is(err, ErrUpstreamTimeout) &&
+		!errors.Is(err, ErrUpstreamError)
+}
+
+// fetchOnce performs a single HTTP GET request to the given URL and returns its
+// body. If SWAPI responds with a 404, fetchOnce returns ErrNotFound. If SWAPI
+// doesn't respond within requestTimeout, fetchOnce returns ErrUpstreamTimeout.
+// If SWAPI responds with a 429, fetchOnce returns ErrRateLimited, and if it
+// can't be reached, it responds with any other error status or its response
+// isn't a JSON of at most maxBodySize bytes, fetchOnce returns
+// ErrUpstreamError. The errors of the transient failures worth retrying are
+// retryableErrors.
+func fetchOnce(ctx context.Context, url string) (body []byte, err error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error while building the request :: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, ErrUpstreamTimeout
+	}
+	if errors.Is(err, context.Canceled) {
+		return nil, fmt.Errorf("error while performing the request :: %w", err)
+	}
+	if err != nil {
+		// The rest of errors performing the request are connection errors.
+		return nil, &retryableError{err: fmt.Errorf("%w :: error while performing the request :: %w", ErrUpstreamError, err)}
+	}
+	defer resp.Body.Close()
+
+	if err = checkStatus(resp); err != nil {
+		return nil, err
+	}
+	if err = checkContentType(resp); err != nil {
+		return nil, err
+	}
+
+	// Read one byte more than allowed to know whether the body is too large.
+	body, err = io.ReadAll(io.LimitReader(resp.Body, maxBodySize+1))
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, ErrUpstreamTimeout
+	}
 	if err != nil {
-		return response, fmt.Errorf("error while performing the request :: %v", err)
+		return nil, fmt.Errorf("%w :: error while reading the response body :: %w", ErrUpstreamError, err)
+	}
+	if int64(len(body)) > maxBodySize {
+		return nil, fmt.Errorf("%w :: the response body is larger than %d bytes", ErrUpstreamError, maxBodySize)
+	}
+	return body, nil
+}
+
+// checkStatus returns the error matching the status of the given SWAPI
+// response, if it isn't successful. The errors of the statuses worth retrying
+// are retryableErrors.
+func checkStatus(resp *http.Response) error {
+	var err error
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode == http.StatusTooManyRequests:
+		err = fmt.Errorf("%w :: SWAPI responded with status %d", ErrRateLimited, resp.StatusCode)
+	default:
+		err = fmt.Errorf("%w :: SWAPI responded with status %d", ErrUpstreamError, resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	if retryableStatuses[resp.StatusCode] {
+		return &retryableError{
+			err:        err,
+			retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+	return err
+}
+
+// checkContentType returns ErrUpstreamError if the given SWAPI response isn't
+// a JSON.
+func checkContentType(resp *http.Response) error {
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "application/json" {
+		return fmt.Errorf("%w :: SWAPI responded with the %q content type instead of a JSON", ErrUpstreamError, contentType)
+	}
+	return nil
+}
+
+// request performs a HTTP request to the given URL returns its response.
+func request[T Resource](ctx context.Context, url string) (response SwapiResponse[T], err error) {
+	body, err := fetch(ctx, url)
 	if err != nil {
-		return response, fmt.Errorf("error while reading the response body :: %v", err)
+		return response, err
 	}
 
 	if err = json.Unmarshal(body, &response); err != nil {
-		return response, fmt.Errorf("error while parsing the response to a JSON :: %v", err)
+		return response, fmt.Errorf("%w :: error while parsing the response to a JSON :: %v", ErrUpstreamError, err)
 	}
 
 	return response, err
 }
 
+// requestResource performs a HTTP request to the given URL, which must point
+// to a single SWAPI resource, and returns the resource.
+func requestResource[T Resource](ctx context.Context, url string) (resource T, err error) {
+	body, err := fetch(ctx, url)
+	if err != nil {
+		return resource, err
+	}
+
+	if err = json.Unmarshal(body, &resource); err != nil {
+		return resource, fmt.Errorf("%w :: error while parsing the response to a JSON :: %v", ErrUpstreamError, err)
+	}
+
+	return resource, nil
+}
+
 // buildUrl builds the SWAPI URL to request with the given endpoint, page number
-// and search condition.
+// and search condition. The query parameters are URL encoded, so the searches
+// with characters such as "&", "#", "+" or "é" are sent as they are and can't
+// add parameters to the request.
 func buildUrl(endpoint string, pageNumber int, search string) string {
-	url := fmt.Sprintf("%s/%s?page=%d", swapiBaseUrl, endpoint, pageNumber)
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(pageNumber))
 	if search != "" {
-		url = fmt.Sprintf("%s&search=%s", url, search)
+		query.Set("search", search)
 	}
-	return url
+	return fmt.Sprintf("%s/%s?%s", swapiBaseUrl, endpoint, query.Encode())
+}
+
+// buildResourceUrl builds the SWAPI URL to request the resource with the given
+// id in the given endpoint.
+func buildResourceUrl(endpoint string, id int) string {
+	return fmt.Sprintf("%s/%s/%d/", swapiBaseUrl, endpoint, id)
 }
 
 // computePageIdxs returns the min and max page indexes needed to correctly
@@ -110,45 +352,6 @@ func computePageIdxs(offset int, remainingResources, apiPageSize int) indexes {
 	}
 }
 
-// retrievePageRec is a recursive solution to request SWAPI given a variable
-// page size.
-func retrievePageRec[T Resource](
-	resource SwapiResponse[T],
-	endpoint,
-	search string,
-	remainingResources,
-	pageNumber,
-	offset int,
-) (
-	swapiResp SwapiResponse[T],
-	err error,
-) {
-	if remainingResources <= 0 {
-		return resource, nil
-	}
-
-	url := buildUrl(endpoint, pageNumber, search)
-	swapiResp, err = request[T](url)
-	if err != nil {
-		return swapiResp, fmt.Errorf("error while requesting the %s endpoint :: %v", endpoint, err)
-	}
-	if swapiResp.Count == 0 {
-		// If there are no results, return an empty array.
-		return SwapiResponse[T]{
-			Count:   0,
-			Results: []T{},
-		}, nil
-	}
-
-	idxs := computePageIdxs(offset, remainingResources, swapiPageSize)
-	numElementsAdded := idxs.max - idxs.min
-	swapiResp.Results = append(resource.Results, swapiResp.Results[idxs.min:idxs.max]...)
-
-	remainingResources = int(math.Min(float64(remainingResources), float64(swapiResp.Count)))
-
-	return retrievePageRec(swapiResp, endpoint, search, remainingResources-numElementsAdded, pageNumber+1, 0)
-}
-
 // computeInitialPage computes the number of the page to request and its offset
 // based on the given page number, requested page size and API page size. If the
 // page number, page size or API page size are lower than one,
@@ -163,35 +366,151 @@ func computeInitialPage(pageNumber, pageSize, apiPageSize int) page {
 	}
 }
 
-// retrievePage retrieves the resources from the SWAPI with the given page
-// number and size. If search isn't "", all the elements of resp.Results will
-// contain the value of search.
+// retrievePage retrieves the resources from the backend of the resources of
+// type T with the given page number and size. If search isn't "", all the
+// elements of resp.Results will contain the value of search.
 func retrievePage[T Resource](
+	ctx context.Context,
 	endpoint string,
 	params internalRequest.RequestParams,
 ) (
 	resp SwapiResponse[T],
 	err error,
 ) {
+	// A page starting beyond math.MaxInt is out of range of any collection.
+	if params.Page-1 > math.MaxInt/params.PageSize {
+		return SwapiResponse[T]{
+			Count:   0,
+			Results: []T{},
+		}, nil
+	}
+
+	backend := backendFor[T](ctx)
 	page := computeInitialPage(params.Page, params.PageSize, swapiPageSize)
-	return retrievePageRec(SwapiResponse[T]{}, endpoint, params.Search, params.PageSize, page.number, page.offset)
+	first, err := listPage(ctx, backend, page.number, params.Search)
+	if err != nil {
+		return resp, fmt.Errorf("error while requesting the %s endpoint :: %w", endpoint, err)
+	}
+	if first.Count == 0 || len(first.Results) <= page.offset {
+		// If there are no results in the page, return an empty array.
+		return SwapiResponse[T]{
+			Count:   first.Count,
+			Results: []T{},
+		}, nil
+	}
+
+	// Once the first page tells the number of resources, the rest of SWAPI
+	// pages needed are known and they can be requested concurrently.
+	firstIdx := (params.Page - 1) * params.PageSize
+	numResources := int(math.Min(float64(params.PageSize), float64(first.Count-firstIdx)))
+	lastPageNumber := (firstIdx+numResources-1)/swapiPageSize + 1
+	pages, err := listPages(ctx, backend, params.Search, page.number+1, lastPageNumber)
+	if err != nil {
+		return resp, fmt.Errorf("error while requesting the %s endpoint :: %w", endpoint, err)
+	}
+
+	results := first.Results
+	for _, p := range pages {
+		results = append(results, p.Results...)
+	}
+	idxs := computePageIdxs(page.offset, numResources, len(results))
+	return SwapiResponse[T]{
+		Count:   first.Count,
+		Results: results[idxs.min:idxs.max],
+	}, nil
+}
+
+// listPages lists concurrently the pages of the given backend from the page
+// number first to the page number last, both included, and returns them in
+// order. If search isn't "", only the resources whose name contains it are
+// listed. If first is greater than last, listPages returns no pages.
+func listPages[T Resource](
+	ctx context.Context,
+	backend Backend[T],
+	search string,
+	first,
+	last int,
+) (
+	pages []SwapiResponse[T],
+	err error,
+) {
+	if first > last {
+		return nil, nil
+	}
+	pages = make([]SwapiResponse[T], last-first+1)
+	err = runConcurrently(ctx, len(pages), func(ctx context.Context, i int) error {
+		var err error
+		pages[i], err = listPage(ctx, backend, first+i, search)
+		return err
+	})
+	return pages, err
+}
+
+// retrieveAllGroup deduplicates the concurrent crawls of the same SWAPI
+// collection.
+var retrieveAllGroup flightGroup[crawlKey, crawlResult]
+
+// crawlKey identifies a crawl of a collection of a backend.
+type crawlKey struct {
+	// backend is the backend the collection is crawled from.
+	backend any
+	// url is the SWAPI URL of the first page of the collection.
+	url string
+}
+
+// crawlResult is the result of a crawl of a SWAPI collection.
+type crawlResult struct {
+	// resp is the SwapiResponse with all the resources of the collection.
+	resp any
+	// servedBy are the base URLs of the SWAPI mirrors that served the crawl.
+	servedBy []string
 }
 
 // retrieveAll returns all the resources in the given SWAPI endpoint. If search
 // isn't "", the resources returned will contain the value of search in their
-// name.
+// name. Concurrent calls for the same backend, endpoint and search share a
+// single crawl of the collection, but each of them gets its own copy of the
+// results, so they can be sorted independently.
 func retrieveAll[T Resource](
+	ctx context.Context,
 	endpoint,
 	search string,
 ) (
 	swapiResp SwapiResponse[T],
 	err error,
 ) {
-	url := buildUrl(endpoint, 1, search)
+	backend := backendFor[T](ctx)
+	key := crawlKey{backend: backend, url: buildUrl(endpoint, 1, search)}
+	result, err, _ := retrieveAllGroup.do(ctx, key, func(ctx context.Context) (crawlResult, error) {
+		ctx = WithServedBy(ctx)
+		resp, err := crawlAll(ctx, backend, endpoint, search)
+		return crawlResult{resp: resp, servedBy: ServedBy(ctx)}, err
+	})
+	for _, baseUrl := range result.servedBy {
+		recordServedBy(ctx, baseUrl)
+	}
+	swapiResp, _ = result.resp.(SwapiResponse[T])
+	swapiResp.Results = slices.Clone(swapiResp.Results)
+	return swapiResp, err
+}
 
-	swapiResp, err = request[T](url)
+// crawlAll lists all the pages of the given backend, which serves the resources
+// of the given SWAPI endpoint, and returns all their resources. If search
+// isn't "", the resources returned will contain the value of search in their
+// name. Once the first page tells the number of resources, the rest of the
+// pages are requested concurrently.
+func crawlAll[T Resource](
+	ctx context.Context,
+	backend Backend[T],
+	endpoint,
+	search string,
+) (
+	swapiResp SwapiResponse[T],
+	err error,
+) {
+	swapiResp, err = listPage(ctx, backend, 1, search)
 	if err != nil {
-		return swapiResp, fmt.Errorf("error while requesting the %s endpoint :: %v", endpoint, err)
+		return swapiResp, fmt.Errorf("error while requesting the %s endpoint :: %w", endpoint, err)
 	}
 	if swapiResp.Count == 0 {
 		// If there are no results, return an empty array.
@@ -201,72 +520,177 @@ func retrieveAll[T Resource](
 		}, nil
 	}
 
-	for swapiResp.Next != nil {
-		nextSwapiResp, err := request[T](*swapiResp.Next)
-		if err != nil {
-			return swapiResp, fmt.Errorf("error while requesting the %s endpoint :: %v", endpoint, err)
-		}
-		swapiResp.Results = append(swapiResp.Results, nextSwapiResp.Results...)
-		swapiResp.Next = nextSwapiResp.Next
+	numPages := (swapiResp.Count + swapiPageSize - 1) / swapiPageSize
+	pages, err := listPages(ctx, backend, search, 2, numPages)
+	if err != nil {
+		return swapiResp, fmt.Errorf("error while requesting the %s endpoint :: %w", endpoint, err)
 	}
+	for _, p := range pages {
+		swapiResp.Results = append(swapiResp.Results, p.Results...)
+	}
+	swapiResp.Next = nil
 
-	return swapiResp, err
+	return swapiResp, nil
 }
 
-// SortResults sorts the given slice of results based on the given sort
-// criteria.
-func SortResults[T Resource](results []T, sortCriteria internalRequest.SortCriteria) error {
-	var lessFn func(i, j int) bool
-	switch sortCriteria.Field {
-	case internalRequest.NameSortField:
-		lessFn = func(i, j int) bool {
-			return results[i].GetName() < results[j].GetName()
-		}
-
-	case internalRequest.CreatedSortField:
-		lessFn = func(i, j int) bool {
-			return results[i].GetCreated().Before(results[j].GetCreated())
-		}
-	default:
+// SortResults sorts the given slice of results by the given sort keys, in
+// order, so the later keys only break the ties of the former ones. The field of
+// each key can be any field of the resource T, as ValidateSortCriteria
+// validates. The numeric fields are compared as numbers and the resources
+// whose value for a field isn't known are placed last. The resources that tie
+// on all the keys are sorted by id, so the order is always the same. If a
+// field isn't valid, SortResults returns ErrInvalidSortField.
+func SortResults[T Resource](results []T, sortKeys ...internalRequest.SortCriteria) error {
+	if len(sortKeys) == 0 {
 		return ErrInvalidSortField
 	}
-	sort.Slice(results, lessFn)
-
-	if sortCriteria.Order == internalRequest.DescendingOrder {
-		utils.ReverseSlice(results)
+	for _, sortKey := range sortKeys {
+		if err := ValidateSortCriteria[T](sortKey); err != nil {
+			return err
+		}
 	}
+	slices.SortStableFunc(results, compareResourcesByKeys[T](sortKeys))
 	return nil
 }
 
-// retrieveAllAndSort retrieves all the resources in SWAPI and sorts them using
-// the given criteria to return the information paginated with the given page
-// number and size. If search isn't "", the names of the resource in resp.Result
-// will contain the value of search.
+// retrieveAllAndSort retrieves all the resources in SWAPI, searches them with
+// the full-text search query in params.Query, filters them with
+// params.Filters and params.FilterExpression and, if params.Sort isn't empty,
+// sorts them by those keys to return the information paginated with the given
+// page number and size. If params.Search isn't "", the names of the resources
+// in resp.Result will contain it or, if params.Fuzzy, be similar to it, and if
+// no name contains it, resp.Suggestions has the names similar to it. Without
+// sort keys, the results of a full-text search are sorted from the most to the
+// least relevant, and the results of a fuzzy search from the most to the least
+// similar. If both are requested, the fuzzy search only filters the results
+// of the full-text search, which stay sorted by relevance.
 func retrieveAllAndSort[T Resource](
+	ctx context.Context,
 	endpoint string,
 	params internalRequest.RequestParams,
 ) (
 	resp SwapiResponse[T],
 	err error,
 ) {
-	resources, err := retrieveAll[T](endpoint, params.Search)
+	search := params.Search
+	if params.Fuzzy {
+		// The fuzzy search needs the whole collection to find the similar
+		// names.
+		search = ""
+	}
+	resources, err := retrieveAll[T](ctx, endpoint, search)
 	if err != nil {
 		return resp, err
 	}
+	var suggestions []string
+	if params.Fuzzy && params.Search != "" {
+		// The whole collection is already retrieved, so the suggestions don't
+		// need another crawl.
+		suggestions = nameSuggestions(resources.Results, params.Search)
+	}
 
-	if err = SortResults(resources.Results, *params.SortCriteria); err != nil {
-		return resp, err
+	if params.Query != "" {
+		// Only the whole collection is cached, as the results of a search
+		// change with the search.
+		key := ""
+		if params.Search == "" {
+			key = endpoint
+		}
+		resources.Results = searchResults(key, resources.Results, params.Query)
+	}
+	switch {
+	case params.Fuzzy && params.Query != "":
+		resources.Results = keepSimilarByName(resources.Results, params.Search)
+	case params.Fuzzy:
+		resources.Results = fuzzyFilterByName(resources.Results, params.Search)
 	}
+	resources.Results = filterResults(resources.Results, params)
+	resources.Count = len(resources.Results)
+	resources.Suggestions = suggestions
 
-	minIdx := (params.Page - 1) * params.PageSize
-	if minIdx > len(resources.Results) {
-		return SwapiResponse[T]{
-			Count:   resources.Count,
-			Results: []T{},
-		}, nil
+	if len(params.Sort) > 0 {
+		if err = SortResults(resources.Results, params.Sort...); err != nil {
+			return resp, err
+		}
 	}
-	maxIdx := int(math.Min(float64(params.Page*params.PageSize), float64(len(resources.Results))))
-	resources.Results = resources.Results[minIdx:maxIdx]
+
+	resources.Results = paginate(resources.Results, params.Page, params.PageSize)
 
 	return resources, nil
 }
+
+// paginate returns the elements of results in the page with the given number
+// and size. If the page is out of range, paginate returns an empty slice.
+// paginate may misbehave if the page number or page size are lower than one.
+func paginate[T any](results []T, pageNumber, pageSize int) []T {
+	// Compare before multiplying to avoid overflows with huge pages.
+	if pageNumber-1 > len(results)/pageSize {
+		return []T{}
+	}
+	minIdx := (pageNumber - 1) * pageSize
+	if minIdx >= len(results) {
+		return []T{}
+	}
+	maxIdx := minIdx + int(math.Min(float64(pageSize), float64(len(results)-minIdx)))
+	return results[minIdx:maxIdx]
+}
+
+// retrieveCollection retrieves the page of resources in the given SWAPI
+// endpoint defined by params. If params requests a full-text or fuzzy search,
+// filtering or sorting, the whole collection is retrieved to search, filter and
+// sort the resources before paginating them. If no name contains the search,
+// resp.Suggestions has the names similar to it. The link fields in
+// params.Expand will be expanded.
+func retrieveCollection[T Resource](
+	ctx context.Context,
+	endpoint string,
+	params internalRequest.RequestParams,
+) (
+	resp SwapiResponse[T],
+	err error,
+) {
+	isFuzzySearch := params.Fuzzy && params.Search != ""
+	if len(params.Sort) > 0 || hasFilters(params) || params.Query != "" || isFuzzySearch {
+		resp, err = retrieveAllAndSort[T](ctx, endpoint, params)
+	} else {
+		resp, err = retrievePage[T](ctx, endpoint, params)
+	}
+	if err != nil {
+		return resp, err
+	}
+
+	// An exact search only needs suggestions when it has no results, and a
+	// fuzzy search gets them along with its results.
+	if params.Search != "" && resp.Count == 0 && !isFuzzySearch {
+		all, err := retrieveAll[T](ctx, endpoint, "")
+		if err != nil {
+			return resp, err
+		}
+		resp.Suggestions = nameSuggestions(all.Results, params.Search)
+	}
+
+	if err = expand(ctx, resp.Results, params.Expand); err != nil {
+		return resp, fmt.Errorf("error while expanding the %s endpoint resources :: %w", endpoint, err)
+	}
+	return resp, nil
+}
+
+// retrieveById retrieves the resource with the given id from the backend of
+// the resources of type T, which are served in the given SWAPI endpoint,
+// expanding the given link fields. If the backend doesn't have a resource with
+// that id, retrieveById returns ErrNotFound.
+func retrieveById[T Resource](ctx context.Context, endpoint string, id int, expandFields []string) (resource T, err error) {
+	resource, err = backendFor[T](ctx).Get(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return resource, err
+	}
+	if err != nil {
+		return resource, fmt.Errorf("error while requesting the %s endpoint :: %w", endpoint, err)
+	}
+
+	resources := []T{resource}
+	if err = expand(ctx, resources, expandFields); err != nil {
+		return resource, fmt.Errorf("error while expanding the %s endpoint resource :: %w", endpoint, err)
+	}
+	return resources[0], nil
+}
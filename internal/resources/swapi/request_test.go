@@ -1,6 +1,16 @@
 package swapi
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -298,6 +308,81 @@ func TestSortResults(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name:    "link_sort_field",
+			results: []Person{{Name: "2"}, {Name: "1"}},
+			sortCriteria: internalRequest.SortCriteria{
+				Field: "homeworld",
+			},
+			expectedResults: []Person{{Name: "2"}, {Name: "1"}},
+			err:             ErrInvalidSortField,
+		},
+		{
+			name:    "sort_by_name_ignoring_case",
+			results: []Person{{Name: "b"}, {Name: "C"}, {Name: "A"}},
+			sortCriteria: internalRequest.SortCriteria{
+				Field: internalRequest.NameSortField,
+			},
+			expectedResults: []Person{{Name: "A"}, {Name: "b"}, {Name: "C"}},
+			err:             nil,
+		},
+		{
+			name:    "sort_by_numeric_field_asc",
+			results: []Person{{Height: "96"}, {Height: "unknown"}, {Height: "1,358"}, {Height: "172"}},
+			sortCriteria: internalRequest.SortCriteria{
+				Field: "height",
+				Order: internalRequest.AscendingOrder,
+			},
+			expectedResults: []Person{{Height: "96"}, {Height: "172"}, {Height: "1,358"}, {Height: "unknown"}},
+			err:             nil,
+		},
+		{
+			name:    "sort_by_numeric_field_desc",
+			results: []Person{{Height: "96"}, {Height: "unknown"}, {Height: "1,358"}, {Height: "172"}},
+			sortCriteria: internalRequest.SortCriteria{
+				Field: "height",
+				Order: internalRequest.DescendingOrder,
+			},
+			expectedResults: []Person{{Height: "1,358"}, {Height: "172"}, {Height: "96"}, {Height: "unknown"}},
+			err:             nil,
+		},
+		{
+			name:    "sort_by_birth_year",
+			results: []Person{{BirthYear: "19BBY"}, {BirthYear: "unknown"}, {BirthYear: "4ABY"}, {BirthYear: "896BBY"}, {BirthYear: "41.9BBY"}},
+			sortCriteria: internalRequest.SortCriteria{
+				Field: "birth_year",
+			},
+			expectedResults: []Person{{BirthYear: "896BBY"}, {BirthYear: "41.9BBY"}, {BirthYear: "19BBY"}, {BirthYear: "4ABY"}, {BirthYear: "unknown"}},
+			err:             nil,
+		},
+		{
+			name:    "sort_by_text_field_desc",
+			results: []Person{{SkinColor: "blue"}, {SkinColor: "n/a"}, {SkinColor: "yellow"}, {SkinColor: "Brown"}},
+			sortCriteria: internalRequest.SortCriteria{
+				Field: "skin_color",
+				Order: internalRequest.DescendingOrder,
+			},
+			expectedResults: []Person{{SkinColor: "yellow"}, {SkinColor: "Brown"}, {SkinColor: "blue"}, {SkinColor: "n/a"}},
+			err:             nil,
+		},
+		{
+			name:    "sort_by_edited",
+			results: []Person{{Edited: now.Add(time.Hour)}, {}, {Edited: now}},
+			sortCriteria: internalRequest.SortCriteria{
+				Field: internalRequest.EditedSortField,
+			},
+			expectedResults: []Person{{Edited: now}, {Edited: now.Add(time.Hour)}, {}},
+			err:             nil,
+		},
+		{
+			name:    "stable_sort",
+			results: []Person{{Name: "1", SkinColor: "fair"}, {Name: "2", SkinColor: "blue"}, {Name: "3", SkinColor: "fair"}},
+			sortCriteria: internalRequest.SortCriteria{
+				Field: "skin_color",
+			},
+			expectedResults: []Person{{Name: "2", SkinColor: "blue"}, {Name: "1", SkinColor: "fair"}, {Name: "3", SkinColor: "fair"}},
+			err:             nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -308,3 +393,506 @@ func TestSortResults(t *testing.T) {
 		})
 	}
 }
+
+func TestRetrieveById(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/people/1/" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"detail":"Not found"}`)
+			return
+		}
+		fmt.Fprint(w, `{"name":"Luke Skywalker"}`)
+	}))
+	defer server.Close()
+	useServer(t, server.URL)
+
+	testCases := []struct {
+		name   string
+		id     int
+		person Person
+		err    error
+	}{
+		{
+			name:   "existing_person",
+			id:     1,
+			person: Person{Name: "Luke Skywalker"},
+			err:    nil,
+		},
+		{
+			name:   "missing_person",
+			id:     2,
+			person: Person{},
+			err:    ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			person, err := retrieveById[Person](context.Background(), peopleEndpoint, tc.id, nil)
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.person, person)
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		results    []int
+		pageNumber int
+		pageSize   int
+		page       []int
+	}{
+		{
+			name:       "nil_results",
+			results:    nil,
+			pageNumber: 1,
+			pageSize:   2,
+			page:       []int{},
+		},
+		{
+			name:       "first_page",
+			results:    []int{1, 2, 3, 4, 5},
+			pageNumber: 1,
+			pageSize:   2,
+			page:       []int{1, 2},
+		},
+		{
+			name:       "last_incomplete_page",
+			results:    []int{1, 2, 3, 4, 5},
+			pageNumber: 3,
+			pageSize:   2,
+			page:       []int{5},
+		},
+		{
+			name:       "page_out_of_range",
+			results:    []int{1, 2, 3, 4, 5},
+			pageNumber: 4,
+			pageSize:   2,
+			page:       []int{},
+		},
+		{
+			name:       "huge_page_number",
+			results:    []int{1, 2, 3, 4, 5},
+			pageNumber: math.MaxInt,
+			pageSize:   15,
+			page:       []int{},
+		},
+		{
+			name:       "huge_page_size",
+			results:    []int{1, 2, 3, 4, 5},
+			pageNumber: 1,
+			pageSize:   math.MaxInt,
+			page:       []int{1, 2, 3, 4, 5},
+		},
+		{
+			name:       "huge_page_size_second_page",
+			results:    []int{1, 2, 3, 4, 5},
+			pageNumber: 2,
+			pageSize:   math.MaxInt,
+			page:       []int{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			page := paginate(tc.results, tc.pageNumber, tc.pageSize)
+			require.Equal(t, tc.page, page)
+		})
+	}
+}
+
+func TestFetch_Cache(t *testing.T) {
+	var numRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		numRequests.Add(1)
+		fmt.Fprint(w, `{"name":"Luke Skywalker"}`)
+	}))
+	defer server.Close()
+	useServer(t, server.URL)
+
+	url := server.URL + "/people/1/"
+	statsBefore := CacheStats()
+
+	for range 3 {
+		body, err := fetch(context.Background(), url)
+		require.NoError(t, err)
+		require.Equal(t, `{"name":"Luke Skywalker"}`, string(body))
+	}
+
+	// Only the first fetch must reach the server.
+	require.Equal(t, int32(1), numRequests.Load())
+	statsAfter := CacheStats()
+	require.Equal(t, statsBefore.Hits+2, statsAfter.Hits)
+	require.Equal(t, statsBefore.Misses+1, statsAfter.Misses)
+}
+
+func TestRetrieveAll_Concurrent(t *testing.T) {
+	var numRequests atomic.Int32
+	release := make(chan struct{})
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		numRequests.Add(1)
+		<-release
+		if r.URL.Query().Get("page") == "1" {
+			fmt.Fprintf(w, `{"count":11,"next":"%s/people?page=2","results":[{"name":"Luke Skywalker"}]}`, server.URL)
+			return
+		}
+		fmt.Fprint(w, `{"count":11,"next":null,"results":[{"name":"C-3PO"}]}`)
+	}))
+	defer server.Close()
+	useServer(t, server.URL)
+
+	const numCallers = 10
+	var wg sync.WaitGroup
+	resps := make([]SwapiResponse[Person], numCallers)
+	for i := range numCallers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			resps[i], err = retrieveAll[Person](context.Background(), peopleEndpoint, "")
+			require.NoError(t, err)
+		}()
+	}
+	// Wait for the first page to be requested before letting the server
+	// respond, so the callers overlap.
+	require.Eventually(t, func() bool { return numRequests.Load() == 1 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// The collection must be crawled only once.
+	require.Equal(t, int32(2), numRequests.Load())
+	for _, resp := range resps {
+		require.Equal(t, []Person{{Name: "Luke Skywalker"}, {Name: "C-3PO"}}, resp.Results)
+	}
+
+	// Each caller must get its own copy of the results.
+	resps[0].Results[0].Name = "<name>"
+	require.Equal(t, "Luke Skywalker", resps[1].Results[0].Name)
+}
+
+func TestRetrievePage_MultiplePages(t *testing.T) {
+	const numPeople = 25
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var pageNumber int
+		fmt.Sscan(r.URL.Query().Get("page"), &pageNumber)
+		first := (pageNumber - 1) * swapiPageSize
+		if pageNumber < 1 || first >= numPeople {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"detail":"Not found"}`)
+			return
+		}
+		results := make([]Person, 0, swapiPageSize)
+		for i := first; i < min(first+swapiPageSize, numPeople); i++ {
+			results = append(results, Person{Name: fmt.Sprint(i)})
+		}
+		resp, _ := json.Marshal(SwapiResponse[Person]{Count: numPeople, Results: results})
+		w.Write(resp)
+	}))
+	defer server.Close()
+	useServer(t, server.URL)
+
+	testCases := []struct {
+		name       string
+		pageNumber int
+		pageSize   int
+		count      int
+		names      []string
+	}{
+		{
+			name:       "within_one_swapi_page",
+			pageNumber: 2,
+			pageSize:   3,
+			count:      numPeople,
+			names:      []string{"3", "4", "5"},
+		},
+		{
+			name:       "across_swapi_pages",
+			pageNumber: 1,
+			pageSize:   22,
+			count:      numPeople,
+			names: []string{
+				"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10",
+				"11", "12", "13", "14", "15", "16", "17", "18", "19", "20", "21",
+			},
+		},
+		{
+			name:       "last_incomplete_page",
+			pageNumber: 3,
+			pageSize:   12,
+			count:      numPeople,
+			names:      []string{"24"},
+		},
+		{
+			name:       "page_out_of_range",
+			pageNumber: 4,
+			pageSize:   10,
+			count:      0,
+			names:      []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := retrievePage[Person](context.Background(), peopleEndpoint, internalRequest.RequestParams{
+				Page:     tc.pageNumber,
+				PageSize: tc.pageSize,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.count, resp.Count)
+			names := make([]string, 0, len(resp.Results))
+			for _, person := range resp.Results {
+				names = append(names, person.Name)
+			}
+			require.Equal(t, tc.names, names)
+		})
+	}
+}
+
+func TestFetch_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		<-release
+	}))
+	defer server.Close()
+	useServer(t, server.URL)
+	defer close(release)
+
+	originalTimeout := requestTimeout
+	requestTimeout = 10 * time.Millisecond
+	defer func() { requestTimeout = originalTimeout }()
+
+	_, err := fetch(context.Background(), server.URL+"/people/1/")
+	require.ErrorIs(t, err, ErrUpstreamTimeout)
+}
+
+func TestFetch_Canceled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		<-release
+	}))
+	defer server.Close()
+	useServer(t, server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := fetch(ctx, server.URL+"/people/1/")
+	require.ErrorIs(t, err, context.Canceled)
+
+	// Wait for the shared request, which isn't canceled, to finish.
+	close(release)
+	require.Eventually(t, func() bool {
+		fetchGroup.mu.Lock()
+		defer fetchGroup.mu.Unlock()
+		return len(fetchGroup.calls) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestFetch_InvalidResponses(t *testing.T) {
+	setRetryConfig(t, 1, time.Millisecond, 10*time.Millisecond)
+	originalMaxBodySize := maxBodySize
+	maxBodySize = 32
+	defer func() { maxBodySize = originalMaxBodySize }()
+
+	testCases := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		err         error
+	}{
+		{
+			name:        "not_found",
+			status:      http.StatusNotFound,
+			contentType: "application/json",
+			body:        `{"detail":"Not found"}`,
+			err:         ErrNotFound,
+		},
+		{
+			name:        "rate_limited",
+			status:      http.StatusTooManyRequests,
+			contentType: "application/json",
+			body:        `{"detail":"Too many requests"}`,
+			err:         ErrRateLimited,
+		},
+		{
+			name:        "bad_request",
+			status:      http.StatusBadRequest,
+			contentType: "application/json",
+			body:        `{"detail":"Bad request"}`,
+			err:         ErrUpstreamError,
+		},
+		{
+			name:        "internal_server_error",
+			status:      http.StatusInternalServerError,
+			contentType: "text/html",
+			body:        `<h1>Server Error</h1>`,
+			err:         ErrUpstreamError,
+		},
+		{
+			name:        "html_page",
+			status:      http.StatusOK,
+			contentType: "text/html; charset=utf-8",
+			body:        `<h1>Not a JSON</h1>`,
+			err:         ErrUpstreamError,
+		},
+		{
+			name:        "body_too_large",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        `{"name":"Luke Skywalker","height":"172"}`,
+			err:         ErrUpstreamError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tc.contentType)
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.body)
+			}))
+			defer server.Close()
+			useServer(t, server.URL)
+
+			_, err := fetch(context.Background(), server.URL+"/people/1/")
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestFetch_JsonContentType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		fmt.Fprint(w, `{"name":"Luke Skywalker"}`)
+	}))
+	defer server.Close()
+	useServer(t, server.URL)
+
+	body, err := fetch(context.Background(), server.URL+"/people/1/")
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"Luke Skywalker"}`, string(body))
+}
+
+// hostileSearches are searches with characters that have a meaning in URLs or
+// that aren't ASCII.
+var hostileSearches = []string{
+	"padmé",
+	"PADMÉ",
+	"Straße",
+	"r2 d2",
+	"luke&page=9",
+	"c-3po#films",
+	"1+1=2",
+	"100%",
+	"?search=yoda",
+	"обиван",
+	"ヨーダ",
+}
+
+func TestBuildUrl(t *testing.T) {
+	for _, search := range hostileSearches {
+		t.Run(search, func(t *testing.T) {
+			rawUrl := buildUrl(peopleEndpoint, 2, search)
+			require.True(t, strings.HasPrefix(rawUrl, swapiBaseUrl+"/"+peopleEndpoint+"?"))
+
+			parsedUrl, err := url.Parse(rawUrl)
+			require.NoError(t, err)
+			require.Empty(t, parsedUrl.Fragment)
+			require.Equal(t, url.Values{
+				"page":   []string{"2"},
+				"search": []string{search},
+			}, parsedUrl.Query())
+		})
+	}
+
+	require.Equal(t, swapiBaseUrl+"/"+peopleEndpoint+"?page=1", buildUrl(peopleEndpoint, 1, ""))
+}
+
+func TestRetrievePage_HostileSearch(t *testing.T) {
+	var mu sync.Mutex
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query())
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"count":0,"next":null,"results":[]}`)
+	}))
+	defer server.Close()
+	useServer(t, server.URL)
+
+	for _, search := range hostileSearches {
+		// Each search is unique to this test, so it isn't served by the cache.
+		search = search + " hostile-search-test"
+		_, err := retrievePage[Person](context.Background(), peopleEndpoint, internalRequest.RequestParams{
+			Page:     1,
+			PageSize: 10,
+			Search:   search,
+		})
+		require.NoError(t, err)
+
+		mu.Lock()
+		lastQuery := queries[len(queries)-1]
+		mu.Unlock()
+		require.Equal(t, url.Values{
+			"page":   []string{"1"},
+			"search": []string{search},
+		}, lastQuery)
+	}
+}
+
+func TestRetrievePeople_AccentedSearch(t *testing.T) {
+	ctx := WithBackends(context.Background(), MemoryBackends(Dataset{People: []Person{
+		{Name: "Padmé Amidala", Url: "https://swapi.dev/api/people/35/"},
+		{Name: "Padme\u0301 Naberrie", Url: "https://swapi.dev/api/people/90/"},
+		{Name: "Luke Skywalker", Url: "https://swapi.dev/api/people/1/"},
+	}}))
+
+	testCases := []struct {
+		name   string
+		search string
+		names  []string
+	}{
+		{
+			name:   "composed_accent",
+			search: "padmé",
+			names:  []string{"Padmé Amidala", "Padme\u0301 Naberrie"},
+		},
+		{
+			name:   "decomposed_accent",
+			search: "padme\u0301",
+			names:  []string{"Padmé Amidala", "Padme\u0301 Naberrie"},
+		},
+		{
+			name:   "url_characters",
+			search: "luke&page=2",
+			names:  []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			people, err := RetrievePeople(ctx, internalRequest.RequestParams{
+				Page:     1,
+				PageSize: 10,
+				Search:   tc.search,
+			})
+			require.NoError(t, err)
+
+			names := []string{}
+			for _, person := range people.Results {
+				names = append(names, person.Name)
+			}
+			require.Equal(t, tc.names, names)
+		})
+	}
+}
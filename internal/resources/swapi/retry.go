@@ -0,0 +1,65 @@
+package swapi
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// retryableStatuses are the SWAPI response statuses of the transient failures
+// worth retrying.
+var retryableStatuses = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// retryableError is an error of a request to SWAPI that may succeed if it's
+// performed again.
+type retryableError struct {
+	err error
+	// retryAfter is the time SWAPI asked to wait before retrying, if any.
+	retryAfter time.Duration
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// backoffDelay returns the time to wait before retrying after the given failed
+// attempt, starting at one. The delay doubles with every attempt, from
+// retryBaseDelay up to retryMaxDelay, and is randomized between its half and
+// its full value to avoid retrying in lockstep with other callers. If
+// retryAfter is longer, backoffDelay returns retryAfter, up to retryMaxDelay,
+// so a Retry-After header asking to wait longer than retryMaxDelay is clamped
+// to it.
+func backoffDelay(attempt int, retryAfter time.Duration) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempt && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, retryMaxDelay)
+	if delay > 0 {
+		delay = delay/2 + rand.N(delay/2+1)
+	}
+	return max(delay, min(retryAfter, retryMaxDelay))
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which can be a
+// number of seconds or a HTTP date, and returns the time to wait from now. If
+// the value is invalid or in the past, parseRetryAfter returns zero.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
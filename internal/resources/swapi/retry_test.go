@@ -0,0 +1,224 @@
+package swapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// setRetryConfig sets the retry configuration for a test and restores the
+// original one when the test finishes.
+func setRetryConfig(t *testing.T, maxAttempts int, baseDelay, maxDelay time.Duration) {
+	originalMaxAttempts, originalBaseDelay, originalMaxDelay := retryMaxAttempts, retryBaseDelay, retryMaxDelay
+	retryMaxAttempts, retryBaseDelay, retryMaxDelay = maxAttempts, baseDelay, maxDelay
+	t.Cleanup(func() {
+		retryMaxAttempts, retryBaseDelay, retryMaxDelay = originalMaxAttempts, originalBaseDelay, originalMaxDelay
+	})
+}
+
+func TestBackoffDelay(t *testing.T) {
+	setRetryConfig(t, 3, 100*time.Millisecond, time.Second)
+
+	testCases := []struct {
+		name       string
+		attempt    int
+		retryAfter time.Duration
+		min        time.Duration
+		max        time.Duration
+	}{
+		{
+			name:    "first_attempt",
+			attempt: 1,
+			min:     50 * time.Millisecond,
+			max:     100 * time.Millisecond,
+		},
+		{
+			name:    "third_attempt",
+			attempt: 3,
+			min:     200 * time.Millisecond,
+			max:     400 * time.Millisecond,
+		},
+		{
+			name:    "capped_attempt",
+			attempt: 100,
+			min:     500 * time.Millisecond,
+			max:     time.Second,
+		},
+		{
+			name:       "longer_retry_after",
+			attempt:    1,
+			retryAfter: 700 * time.Millisecond,
+			min:        700 * time.Millisecond,
+			max:        700 * time.Millisecond,
+		},
+		{
+			name:       "retry_after_longer_than_max_delay",
+			attempt:    1,
+			retryAfter: time.Minute,
+			min:        time.Second,
+			max:        time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			delay := backoffDelay(tc.attempt, tc.retryAfter)
+			require.GreaterOrEqual(t, delay, tc.min)
+			require.LessOrEqual(t, delay, tc.max)
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name  string
+		value string
+		delay time.Duration
+	}{
+		{
+			name:  "empty",
+			value: "",
+			delay: 0,
+		},
+		{
+			name:  "seconds",
+			value: "3",
+			delay: 3 * time.Second,
+		},
+		{
+			name:  "negative_seconds",
+			value: "-3",
+			delay: 0,
+		},
+		{
+			name:  "http_date",
+			value: now.Add(time.Minute).Format(http.TimeFormat),
+			delay: time.Minute,
+		},
+		{
+			name:  "past_http_date",
+			value: now.Add(-time.Minute).Format(http.TimeFormat),
+			delay: 0,
+		},
+		{
+			name:  "invalid",
+			value: "<invalid>",
+			delay: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			delay := parseRetryAfter(tc.value, now)
+			require.Equal(t, tc.delay, delay)
+		})
+	}
+}
+
+func TestFetch_Retry(t *testing.T) {
+	setRetryConfig(t, 3, time.Millisecond, 10*time.Millisecond)
+
+	testCases := []struct {
+		name             string
+		statuses         []int
+		expectedRequests int32
+		expectError      bool
+	}{
+		{
+			name:             "success_after_retries",
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			expectedRequests: 3,
+			expectError:      false,
+		},
+		{
+			name:             "retries_exhausted",
+			statuses:         []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			expectedRequests: 3,
+			expectError:      true,
+		},
+		{
+			name:             "not_retryable_status",
+			statuses:         []int{http.StatusNotFound, http.StatusOK},
+			expectedRequests: 1,
+			expectError:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var numRequests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				status := tc.statuses[numRequests.Add(1)-1]
+				w.WriteHeader(status)
+				fmt.Fprint(w, `{"name":"Luke Skywalker"}`)
+			}))
+			defer server.Close()
+			useServer(t, server.URL)
+
+			body, err := fetch(context.Background(), server.URL+"/people/1/")
+			require.Equal(t, tc.expectedRequests, numRequests.Load())
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, `{"name":"Luke Skywalker"}`, string(body))
+		})
+	}
+}
+
+func TestFetch_RetryAfterTooLong(t *testing.T) {
+	setRetryConfig(t, 3, time.Millisecond, 10*time.Millisecond)
+
+	var numRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if numRequests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"name":"Luke Skywalker"}`)
+	}))
+	defer server.Close()
+	useServer(t, server.URL)
+
+	start := time.Now()
+	body, err := fetch(context.Background(), server.URL+"/people/1/")
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"Luke Skywalker"}`, string(body))
+	// SWAPI asked to wait longer than the maximum delay, so the retry waits
+	// the maximum delay instead.
+	require.Equal(t, int32(2), numRequests.Load())
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestFetch_DeadlineExceeded(t *testing.T) {
+	setRetryConfig(t, 10, 50*time.Millisecond, 50*time.Millisecond)
+	originalDeadline := upstreamDeadline
+	upstreamDeadline = 120 * time.Millisecond
+	t.Cleanup(func() { upstreamDeadline = originalDeadline })
+
+	var numRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	useServer(t, server.URL)
+
+	start := time.Now()
+	_, err := fetch(context.Background(), server.URL+"/people/1/")
+	require.ErrorIs(t, err, ErrUpstreamTimeout)
+	// The deadline is exceeded before all the attempts are performed.
+	require.Less(t, numRequests.Load(), int32(10))
+	require.Less(t, time.Since(start), time.Second)
+}
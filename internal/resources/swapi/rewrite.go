@@ -0,0 +1,132 @@
+package swapi
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// endpoints are the SWAPI endpoints, which the service serves with the same
+// names.
+var endpoints = []string{
+	peopleEndpoint,
+	planetsEndpoint,
+	filmsEndpoint,
+	speciesEndpoint,
+	vehiclesEndpoint,
+	starshipsEndpoint,
+}
+
+// rewritable is implemented by the resources whose URLs can be rewritten.
+type rewritable interface {
+	rewriteUrls(rewrite func(string) string)
+}
+
+// RewriteUrls returns a copy of the given resources where every SWAPI resource
+// URL, the resources' own, their links' and their expanded resources', points
+// to the same resource in the service API served in baseUrl, e.g.
+// "https://swapi.dev/api/people/1/" becomes "<baseUrl>/people/1". The given
+// resources aren't modified.
+func RewriteUrls[T Resource](resources []T, baseUrl string) []T {
+	rewrite := serviceUrlRewriter(baseUrl)
+	rewritten := make([]T, len(resources))
+	for i, resource := range resources {
+		any(&resource).(rewritable).rewriteUrls(rewrite)
+		rewritten[i] = resource
+	}
+	return rewritten
+}
+
+// RewriteUrl returns a copy of the given resource where every SWAPI resource
+// URL points to the service API served in baseUrl, as RewriteUrls does.
+func RewriteUrl[T Resource](resource T, baseUrl string) T {
+	any(&resource).(rewritable).rewriteUrls(serviceUrlRewriter(baseUrl))
+	return resource
+}
+
+// serviceUrlRewriter returns a function that rewrites a SWAPI resource URL to
+// the URL of the same resource in the service API served in baseUrl. The URLs
+// that aren't SWAPI resource URLs are returned unchanged.
+func serviceUrlRewriter(baseUrl string) func(string) string {
+	baseUrl = strings.TrimRight(baseUrl, "/")
+	return func(resourceUrl string) string {
+		id, err := resourceId(resourceUrl)
+		if err != nil {
+			return resourceUrl
+		}
+		endpoint := resourceEndpoint(resourceUrl)
+		if !slices.Contains(endpoints, endpoint) {
+			return resourceUrl
+		}
+		return fmt.Sprintf("%s/%s/%d", baseUrl, endpoint, id)
+	}
+}
+
+// rewriteAll returns a new list with the result of rewrite for each of the
+// given URLs.
+func rewriteAll(urls []string, rewrite func(string) string) []string {
+	if urls == nil {
+		return nil
+	}
+	rewritten := make([]string, len(urls))
+	for i, u := range urls {
+		rewritten[i] = rewrite(u)
+	}
+	return rewritten
+}
+
+// rewriteOptional returns the result of rewrite for the given URL, which may
+// be nil.
+func rewriteOptional(url *string, rewrite func(string) string) *string {
+	if url == nil {
+		return nil
+	}
+	rewritten := rewrite(*url)
+	return &rewritten
+}
+
+// rewriteUrls returns a new expansion with the URLs of the expanded resources
+// rewritten with rewrite.
+func (e Expansion) rewriteUrls(rewrite func(string) string) Expansion {
+	if e == nil {
+		return nil
+	}
+	rewritten := make(Expansion, len(e))
+	for field, value := range e {
+		rewritten[field] = rewriteExpanded(value, rewrite)
+	}
+	return rewritten
+}
+
+// rewriteExpanded returns a copy of the given expanded resource, or list of
+// resources, with its URLs rewritten with rewrite.
+func rewriteExpanded(value any, rewrite func(string) string) any {
+	switch v := value.(type) {
+	case Person:
+		v.rewriteUrls(rewrite)
+		return v
+	case Planet:
+		v.rewriteUrls(rewrite)
+		return v
+	case Film:
+		v.rewriteUrls(rewrite)
+		return v
+	case Species:
+		v.rewriteUrls(rewrite)
+		return v
+	case Vehicle:
+		v.rewriteUrls(rewrite)
+		return v
+	case Starship:
+		v.rewriteUrls(rewrite)
+		return v
+	case []any:
+		list := make([]any, len(v))
+		for i, resource := range v {
+			list[i] = rewriteExpanded(resource, rewrite)
+		}
+		return list
+	default:
+		return value
+	}
+}
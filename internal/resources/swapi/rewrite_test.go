@@ -0,0 +1,99 @@
+package swapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceUrlRewriter(t *testing.T) {
+	rewrite := serviceUrlRewriter("https://example.com/api/")
+
+	tcs := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{
+			name:     "SWAPI resource URL",
+			url:      "https://swapi.dev/api/people/1/",
+			expected: "https://example.com/api/people/1",
+		},
+		{
+			name:     "mirror resource URL",
+			url:      "https://swapi.py4e.com/api/starships/12",
+			expected: "https://example.com/api/starships/12",
+		},
+		{
+			name:     "unknown endpoint",
+			url:      "https://swapi.dev/api/unknown/1/",
+			expected: "https://swapi.dev/api/unknown/1/",
+		},
+		{
+			name:     "not a resource URL",
+			url:      "https://swapi.dev/api/people/",
+			expected: "https://swapi.dev/api/people/",
+		},
+		{
+			name:     "empty URL",
+			url:      "",
+			expected: "",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, rewrite(tc.url))
+		})
+	}
+}
+
+func TestRewriteUrls(t *testing.T) {
+	homeworld := "https://swapi.dev/api/planets/1/"
+	people := []Person{
+		{
+			Name:      "Luke Skywalker",
+			Homeworld: homeworld,
+			Films:     []string{"https://swapi.dev/api/films/1/"},
+			Url:       "https://swapi.dev/api/people/1/",
+			Expanded: Expansion{
+				homeworldLink: Planet{Name: "Tatooine", Url: homeworld},
+				filmsLink:     []any{Film{Title: "A New Hope", Url: "https://swapi.dev/api/films/1/"}},
+			},
+		},
+	}
+
+	rewritten := RewriteUrls(people, "http://localhost:8080/api")
+	require.Equal(t, []Person{
+		{
+			Name:      "Luke Skywalker",
+			Homeworld: "http://localhost:8080/api/planets/1",
+			Films:     []string{"http://localhost:8080/api/films/1"},
+			Url:       "http://localhost:8080/api/people/1",
+			Expanded: Expansion{
+				homeworldLink: Planet{Name: "Tatooine", Url: "http://localhost:8080/api/planets/1"},
+				filmsLink:     []any{Film{Title: "A New Hope", Url: "http://localhost:8080/api/films/1"}},
+			},
+		},
+	}, rewritten)
+
+	// The original resources aren't modified.
+	require.Equal(t, "https://swapi.dev/api/people/1/", people[0].Url)
+	require.Equal(t, "https://swapi.dev/api/films/1/", people[0].Films[0])
+	require.Equal(t, homeworld, people[0].Expanded[homeworldLink].(Planet).Url)
+}
+
+func TestRewriteUrl(t *testing.T) {
+	homeworld := "https://swapi.dev/api/planets/1/"
+	species := Species{
+		Name:      "Human",
+		Homeworld: &homeworld,
+		People:    []string{"https://swapi.dev/api/people/1/"},
+		Url:       "https://swapi.dev/api/species/1/",
+	}
+
+	rewritten := RewriteUrl(species, "http://localhost:8080/api")
+	require.Equal(t, "http://localhost:8080/api/planets/1", *rewritten.Homeworld)
+	require.Equal(t, []string{"http://localhost:8080/api/people/1"}, rewritten.People)
+	require.Equal(t, "http://localhost:8080/api/species/1", rewritten.Url)
+	require.Equal(t, "https://swapi.dev/api/planets/1/", homeworld)
+}
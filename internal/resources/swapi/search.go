@@ -0,0 +1,117 @@
+package swapi
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"maps"
+	"sync"
+
+	"github.com/pegondo/starwars-service/internal/fulltext"
+)
+
+// searchBoosts are the boosts of the fields in the full-text search, so the
+// resources whose name matches the query rank before the ones where only
+// other fields do.
+var searchBoosts = map[string]float64{
+	"name":  3,
+	"title": 3,
+}
+
+// scorable is implemented by the resources that can hold their relevance for a
+// full-text search query.
+type scorable interface {
+	setScore(score float64)
+}
+
+// longTexted is implemented by the resources with long text fields that are
+// searched with the full-text search but can't be filtered or sorted by, such
+// as the opening crawl of the films.
+type longTexted interface {
+	longTexts() fields
+}
+
+// searchIndexEntry is the full-text index of a collection.
+type searchIndexEntry struct {
+	// signature identifies the indexed resources, so the index is rebuilt
+	// when they change.
+	signature uint64
+	index     *fulltext.Index
+}
+
+// searchIndexes caches the full-text index of each collection by endpoint.
+var searchIndexes = struct {
+	mu      sync.Mutex
+	entries map[string]searchIndexEntry
+}{entries: map[string]searchIndexEntry{}}
+
+// searchResults returns the given resources that match the given full-text
+// search query, from the most to the least relevant, with their scores set.
+// The text fields of the resources are searched, such as their name, climate
+// or hair color, and the long texts such as the films' opening crawls. If key
+// isn't "", the index of the resources is cached with that key until they
+// change. The given resources aren't modified.
+func searchResults[T Resource](key string, resources []T, query string) []T {
+	matches := searchIndex(key, resources).Search(query)
+	results := make([]T, 0, len(matches))
+	for _, match := range matches {
+		resource := resources[match.Doc]
+		any(&resource).(scorable).setScore(match.Score)
+		results = append(results, resource)
+	}
+	return results
+}
+
+// searchIndex returns the full-text index of the given resources. If key isn't
+// "", the index is cached with that key and reused while the resources have
+// the same signature.
+func searchIndex[T Resource](key string, resources []T) *fulltext.Index {
+	if key == "" {
+		return buildSearchIndex(resources)
+	}
+
+	signature := resourcesSignature(resources)
+	searchIndexes.mu.Lock()
+	entry, ok := searchIndexes.entries[key]
+	searchIndexes.mu.Unlock()
+	if ok && entry.signature == signature {
+		return entry.index
+	}
+
+	index := buildSearchIndex(resources)
+	searchIndexes.mu.Lock()
+	searchIndexes.entries[key] = searchIndexEntry{signature: signature, index: index}
+	searchIndexes.mu.Unlock()
+	return index
+}
+
+// buildSearchIndex returns the full-text index of the text fields of the given
+// resources.
+func buildSearchIndex[T Resource](resources []T) *fulltext.Index {
+	docs := make([]fulltext.Document, 0, len(resources))
+	for _, resource := range resources {
+		doc := fulltext.Document{}
+		for field, value := range resource.fields() {
+			if !numericFields[field] {
+				doc[field] = value
+			}
+		}
+		if resource, ok := any(resource).(longTexted); ok {
+			maps.Copy(doc, resource.longTexts())
+		}
+		docs = append(docs, doc)
+	}
+	return fulltext.Build(docs, searchBoosts)
+}
+
+// resourcesSignature returns a hash of the URLs and edition times of the given
+// resources, in order, which changes when any of them does.
+func resourcesSignature[T Resource](resources []T) uint64 {
+	h := fnv.New64a()
+	var edited [8]byte
+	for _, resource := range resources {
+		h.Write([]byte(resource.GetUrl()))
+		binary.LittleEndian.PutUint64(edited[:], uint64(resource.GetEdited().UnixNano()))
+		h.Write(edited[:])
+	}
+	return h.Sum64()
+}
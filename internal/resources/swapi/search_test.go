@@ -0,0 +1,152 @@
+package swapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	internalRequest "github.com/pegondo/starwars-service/internal/request"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchResults(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+		names []string
+	}{
+		{
+			name:  "no_match",
+			query: "ocean",
+			names: []string{},
+		},
+		{
+			name:  "name_ranks_first",
+			query: "hoth",
+			names: []string{"Hoth"},
+		},
+		{
+			name:  "many_fields",
+			query: "arid desert",
+			names: []string{"Tatooine", "Geonosis"},
+		},
+		{
+			name:  "prefix",
+			query: "mountain",
+			names: []string{"Alderaan", "Geonosis", "Hoth"},
+		},
+		{
+			name:  "numeric_fields_arent_searched",
+			query: "200000",
+			names: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			planets := testPlanets()
+			results := searchResults("", planets, tc.query)
+
+			names := []string{}
+			for i, planet := range results {
+				require.NotNil(t, planet.Score)
+				if i > 0 {
+					require.GreaterOrEqual(t, *results[i-1].Score, *planet.Score)
+				}
+				names = append(names, planet.Name)
+			}
+			require.ElementsMatch(t, tc.names, names)
+			for _, planet := range planets {
+				require.Nil(t, planet.Score)
+			}
+		})
+	}
+}
+
+func TestSearchResults_LongTexts(t *testing.T) {
+	films := []Film{
+		{Title: "A New Hope", OpeningCrawl: "It is a period of civil war. Rebel spaceships...", Url: "https://swapi.dev/api/films/1/"},
+		{Title: "The Empire Strikes Back", OpeningCrawl: "It is a dark time for the Rebellion...", Url: "https://swapi.dev/api/films/2/"},
+	}
+
+	results := searchResults("", films, "civil war")
+	require.Len(t, results, 1)
+	require.Equal(t, "A New Hope", results[0].Title)
+}
+
+func TestSearchIndex_Cache(t *testing.T) {
+	const key = "test-search-index-cache"
+	planets := testPlanets()
+
+	index := searchIndex(key, planets)
+	require.Same(t, index, searchIndex(key, planets))
+	require.NotSame(t, index, searchIndex("", planets))
+
+	planets[0].Edited = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	rebuilt := searchIndex(key, planets)
+	require.NotSame(t, index, rebuilt)
+	require.Same(t, rebuilt, searchIndex(key, planets))
+}
+
+func TestRetrievePlanets_Query(t *testing.T) {
+	ctx := WithBackends(context.Background(), MemoryBackends(Dataset{Planets: testPlanets()}))
+
+	testCases := []struct {
+		name   string
+		params internalRequest.RequestParams
+		count  int
+		names  []string
+	}{
+		{
+			name: "by_relevance",
+			params: internalRequest.RequestParams{
+				Page:     1,
+				PageSize: 10,
+				Query:    "desert",
+			},
+			count: 2,
+			names: []string{"Tatooine", "Geonosis"},
+		},
+		{
+			name: "sorted",
+			params: internalRequest.RequestParams{
+				Page:     1,
+				PageSize: 10,
+				Query:    "mountain",
+				Sort: []internalRequest.SortCriteria{
+					{Field: internalRequest.NameSortField, Order: internalRequest.DescendingOrder},
+				},
+			},
+			count: 3,
+			names: []string{"Hoth", "Geonosis", "Alderaan"},
+		},
+		{
+			name: "filtered_and_paginated",
+			params: internalRequest.RequestParams{
+				Page:     1,
+				PageSize: 1,
+				Query:    "mountain",
+				Filters: []internalRequest.Filter{
+					{Field: "climate", Values: []string{"temperate"}},
+				},
+			},
+			count: 2,
+			names: []string{"Geonosis"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			planets, err := RetrievePlanets(ctx, tc.params)
+			require.NoError(t, err)
+			require.Equal(t, tc.count, planets.Count)
+
+			names := []string{}
+			for _, planet := range planets.Results {
+				require.NotNil(t, planet.Score)
+				names = append(names, planet.Name)
+			}
+			require.Equal(t, tc.names, names)
+		})
+	}
+}
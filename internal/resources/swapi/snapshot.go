@@ -0,0 +1,94 @@
+package swapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// snapshotVersion is the version of the snapshot format. It must be increased
+// whenever the format changes in a way older versions of the service can't
+// read.
+const snapshotVersion = 1
+
+// Snapshot is a copy of every resource in SWAPI at a given time, which can be
+// served as a dataset without requesting SWAPI.
+type Snapshot struct {
+	// Version is the version of the snapshot format.
+	Version int `json:"version"`
+	// Created is the time when the snapshot was taken.
+	Created time.Time `json:"created"`
+	// Source is the base URL of the SWAPI the snapshot was taken from.
+	Source string `json:"source"`
+	// Dataset holds the resources of the snapshot.
+	Dataset
+}
+
+// TakeSnapshot retrieves all the resources of every collection from the
+// current backends, SWAPI unless they were replaced, and returns them as a
+// snapshot.
+func TakeSnapshot(ctx context.Context) (snapshot Snapshot, err error) {
+	snapshot = Snapshot{
+		Version: snapshotVersion,
+		Created: time.Now().UTC(),
+		Source:  swapiBaseUrl,
+	}
+	if snapshot.People, err = retrieveAllResults[Person](ctx, peopleEndpoint); err != nil {
+		return snapshot, err
+	}
+	if snapshot.Planets, err = retrieveAllResults[Planet](ctx, planetsEndpoint); err != nil {
+		return snapshot, err
+	}
+	if snapshot.Films, err = retrieveAllResults[Film](ctx, filmsEndpoint); err != nil {
+		return snapshot, err
+	}
+	if snapshot.Species, err = retrieveAllResults[Species](ctx, speciesEndpoint); err != nil {
+		return snapshot, err
+	}
+	if snapshot.Vehicles, err = retrieveAllResults[Vehicle](ctx, vehiclesEndpoint); err != nil {
+		return snapshot, err
+	}
+	if snapshot.Starships, err = retrieveAllResults[Starship](ctx, starshipsEndpoint); err != nil {
+		return snapshot, err
+	}
+	return snapshot, nil
+}
+
+// retrieveAllResults returns all the resources in the given SWAPI endpoint.
+func retrieveAllResults[T Resource](ctx context.Context, endpoint string) ([]T, error) {
+	resp, err := retrieveAll[T](ctx, endpoint, "")
+	if err != nil {
+		return nil, fmt.Errorf("error while retrieving the %s collection :: %w", endpoint, err)
+	}
+	return resp.Results, nil
+}
+
+// WriteSnapshot writes the given snapshot as a JSON file in the given path. The
+// file is replaced atomically, so a failed write never leaves a partial
+// snapshot behind.
+func WriteSnapshot(path string, snapshot Snapshot) error {
+	content, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error while encoding the snapshot :: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("error while creating the snapshot file :: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error while writing the snapshot file :: %v", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("error while writing the snapshot file :: %v", err)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error while writing the snapshot file :: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package swapi
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTakeSnapshot(t *testing.T) {
+	dataset := testDataset(25)
+	ctx := WithBackends(context.Background(), MemoryBackends(dataset))
+
+	snapshot, err := TakeSnapshot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, snapshotVersion, snapshot.Version)
+	require.Equal(t, swapiBaseUrl, snapshot.Source)
+	require.False(t, snapshot.Created.IsZero())
+	require.Equal(t, dataset.People, snapshot.People)
+	require.Equal(t, dataset.Planets, snapshot.Planets)
+	require.Empty(t, snapshot.Films)
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, WriteSnapshot(path, snapshot))
+
+	loaded, err := LoadDataset(path)
+	require.NoError(t, err)
+	require.Equal(t, dataset.People, loaded.People)
+	require.Equal(t, dataset.Planets, loaded.Planets)
+}
+
+func TestLoadDataset_UnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	err := os.WriteFile(path, []byte(`{"version":2,"people":[]}`), 0o644)
+	require.NoError(t, err)
+
+	_, err = LoadDataset(path)
+	require.ErrorContains(t, err, "unsupported snapshot version 2")
+}
@@ -0,0 +1,207 @@
+package swapi
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+	"time"
+
+	internalRequest "github.com/pegondo/starwars-service/internal/request"
+	"github.com/pegondo/starwars-service/internal/utils"
+)
+
+const (
+	// createdSortField is the field to sort the resources by creation time.
+	createdSortField = "created"
+	// editedSortField is the field to sort the resources by the last time they
+	// were edited.
+	editedSortField = "edited"
+	// nameSortField is the field to sort the resources by name, which is the
+	// title for films.
+	nameSortField = "name"
+	// birthYearSortField is the field to sort the people by birth year.
+	birthYearSortField = "birth_year"
+)
+
+const (
+	// beforeYavinSuffix is the suffix of the years before the Battle of Yavin,
+	// e.g. "19BBY".
+	beforeYavinSuffix = "bby"
+	// afterYavinSuffix is the suffix of the years after the Battle of Yavin,
+	// e.g. "4ABY".
+	afterYavinSuffix = "aby"
+)
+
+// unknownTexts are the values SWAPI uses for the text fields it doesn't know.
+var unknownTexts = map[string]bool{
+	"":        true,
+	"unknown": true,
+	"n/a":     true,
+}
+
+// sortValue is the value of a field of a resource to sort it by. Only one of
+// time, number or text is set, depending on the field.
+type sortValue struct {
+	// known is whether the resource has a value for the field.
+	known  bool
+	time   time.Time
+	number float64
+	text   string
+}
+
+// compare returns -1, 0 or +1 depending on whether v is less than, equal to or
+// greater than other, which must be a value of the same field.
+func (v sortValue) compare(other sortValue) int {
+	return cmp.Or(
+		v.time.Compare(other.time),
+		cmp.Compare(v.number, other.number),
+		cmp.Compare(v.text, other.text),
+	)
+}
+
+// sortFields returns the fields the resource T can be sorted by: its scalar
+// fields, its name and its creation and edition times.
+func sortFields[T Resource]() map[string]bool {
+	var resource T
+	fields := map[string]bool{
+		nameSortField:    true,
+		createdSortField: true,
+		editedSortField:  true,
+	}
+	for field := range resource.fields() {
+		fields[field] = true
+	}
+	return fields
+}
+
+// ValidateSortCriteria validates that the field of the given sort criteria is
+// a field the resource T can be sorted by. If it isn't, ValidateSortCriteria
+// returns ErrInvalidSortField.
+func ValidateSortCriteria[T Resource](sortCriteria internalRequest.SortCriteria) error {
+	if !sortFields[T]()[string(sortCriteria.Field)] {
+		return ErrInvalidSortField
+	}
+	return nil
+}
+
+// resourceSortValue returns the value of the given field of resource to sort
+// it by. The numeric fields are compared as numbers, the birth years as years
+// relative to the Battle of Yavin and the rest of the fields as text, ignoring
+// the case. The field must be one of sortFields.
+func resourceSortValue[T Resource](resource T, field string) sortValue {
+	switch field {
+	case nameSortField:
+		return textSortValue(resource.GetName())
+	case createdSortField:
+		return timeSortValue(resource.GetCreated())
+	case editedSortField:
+		return timeSortValue(resource.GetEdited())
+	}
+
+	value := resource.fields()[field]
+	switch {
+	case field == birthYearSortField:
+		year, ok := parseBirthYear(value)
+		return sortValue{known: ok, number: year}
+	case numericFields[field]:
+		number, ok := parseNumber(value)
+		return sortValue{known: ok, number: number}
+	default:
+		return textSortValue(value)
+	}
+}
+
+// textSortValue returns the sort value of the given text, which is unknown if
+// SWAPI doesn't know it.
+func textSortValue(text string) sortValue {
+	text = utils.FoldCase(strings.TrimSpace(text))
+	return sortValue{known: !unknownTexts[text], text: text}
+}
+
+// timeSortValue returns the sort value of the given time, which is unknown if
+// it's the zero time.
+func timeSortValue(t time.Time) sortValue {
+	return sortValue{known: !t.IsZero(), time: t}
+}
+
+// parseBirthYear parses the given SWAPI birth year, e.g. "19BBY" or "41.9BBY",
+// to the number of years after the Battle of Yavin, which is negative for the
+// years before it. If the birth year isn't known, parseBirthYear returns false.
+func parseBirthYear(value string) (float64, bool) {
+	value = utils.FoldCase(strings.TrimSpace(value))
+	sign := 1.0
+	switch {
+	case strings.HasSuffix(value, beforeYavinSuffix):
+		sign = -1
+		value = strings.TrimSuffix(value, beforeYavinSuffix)
+	case strings.HasSuffix(value, afterYavinSuffix):
+		value = strings.TrimSuffix(value, afterYavinSuffix)
+	default:
+		return 0, false
+	}
+	year, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, false
+	}
+	return sign * year, true
+}
+
+// compareResources returns a function that compares two resources of type T
+// by the field of the given sort criteria, in its order. The resources whose
+// value for the field isn't known, such as "unknown" heights, are placed last
+// in both orders.
+func compareResources[T Resource](sortCriteria internalRequest.SortCriteria) func(a, b T) int {
+	field := string(sortCriteria.Field)
+	return func(a, b T) int {
+		valueA, valueB := resourceSortValue(a, field), resourceSortValue(b, field)
+		switch {
+		case !valueA.known || !valueB.known:
+			// The unknown values go after the known ones.
+			return cmp.Compare(boolToInt(!valueA.known), boolToInt(!valueB.known))
+		case sortCriteria.Order == internalRequest.DescendingOrder:
+			return valueB.compare(valueA)
+		default:
+			return valueA.compare(valueB)
+		}
+	}
+}
+
+// compareResourcesByKeys returns a function that compares two resources of
+// type T by each of the given sort keys in turn, so the later keys only break
+// the ties of the former ones. The resources that tie on all the keys are
+// compared by id and then by URL, so the same resources are always sorted in
+// the same order and the pages don't repeat or skip any of them.
+func compareResourcesByKeys[T Resource](sortKeys []internalRequest.SortCriteria) func(a, b T) int {
+	compareFns := make([]func(a, b T) int, 0, len(sortKeys))
+	for _, sortKey := range sortKeys {
+		compareFns = append(compareFns, compareResources[T](sortKey))
+	}
+	return func(a, b T) int {
+		for _, compareFn := range compareFns {
+			if c := compareFn(a, b); c != 0 {
+				return c
+			}
+		}
+		return compareIds(a.GetUrl(), b.GetUrl())
+	}
+}
+
+// compareIds compares two resources by the ids in their URLs and then by the
+// URLs themselves. The resources whose URL has no id go last.
+func compareIds(urlA, urlB string) int {
+	idA, errA := resourceId(urlA)
+	idB, errB := resourceId(urlB)
+	return cmp.Or(
+		cmp.Compare(boolToInt(errA != nil), boolToInt(errB != nil)),
+		cmp.Compare(idA, idB),
+		cmp.Compare(urlA, urlB),
+	)
+}
+
+// boolToInt returns 1 if b is true and 0 otherwise.
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
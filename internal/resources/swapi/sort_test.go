@@ -0,0 +1,156 @@
+package swapi
+
+import (
+	"slices"
+	"testing"
+
+	internalRequest "github.com/pegondo/starwars-service/internal/request"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSortCriteria(t *testing.T) {
+	testCases := []struct {
+		name  string
+		field internalRequest.SortField
+		err   error
+	}{
+		{
+			name:  "name",
+			field: internalRequest.NameSortField,
+		},
+		{
+			name:  "created",
+			field: internalRequest.CreatedSortField,
+		},
+		{
+			name:  "edited",
+			field: internalRequest.EditedSortField,
+		},
+		{
+			name:  "numeric_field",
+			field: "population",
+		},
+		{
+			name:  "text_field",
+			field: "climate",
+		},
+		{
+			name:  "field_of_another_resource",
+			field: "height",
+			err:   ErrInvalidSortField,
+		},
+		{
+			name:  "link_field",
+			field: "residents",
+			err:   ErrInvalidSortField,
+		},
+		{
+			name:  "empty_field",
+			field: "",
+			err:   ErrInvalidSortField,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateSortCriteria[Planet](internalRequest.SortCriteria{Field: tc.field})
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestParseBirthYear(t *testing.T) {
+	testCases := []struct {
+		value string
+		year  float64
+		ok    bool
+	}{
+		{value: "19BBY", year: -19, ok: true},
+		{value: "41.9BBY", year: -41.9, ok: true},
+		{value: "4ABY", year: 4, ok: true},
+		{value: " 0 bby ", year: 0, ok: true},
+		{value: "unknown", ok: false},
+		{value: "19", ok: false},
+		{value: "BBY", ok: false},
+		{value: "", ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			year, ok := parseBirthYear(tc.value)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.year, year)
+		})
+	}
+}
+
+func TestTextSortValue(t *testing.T) {
+	// The texts are case folded, so the ones that only differ in their case
+	// sort as equal, including the letters such as "ß".
+	require.Equal(t, textSortValue("strasse"), textSortValue(" Straße "))
+	require.Equal(t, textSortValue("strasse"), textSortValue("STRASSE"))
+	require.False(t, textSortValue("UNKNOWN").known)
+}
+
+func TestSortResults_ManyKeys(t *testing.T) {
+	people := []Person{
+		{Name: "Leia Organa", SkinColor: "light", Height: "150", Url: "https://swapi.dev/api/people/5/"},
+		{Name: "Luke Skywalker", SkinColor: "fair", Height: "172", Url: "https://swapi.dev/api/people/1/"},
+		{Name: "Owen Lars", SkinColor: "light", Height: "178", Url: "https://swapi.dev/api/people/6/"},
+		{Name: "Beru Whitesun lars", SkinColor: "light", Height: "165", Url: "https://swapi.dev/api/people/7/"},
+		{Name: "Biggs Darklighter", SkinColor: "light", Height: "183", Url: "https://swapi.dev/api/people/9/"},
+		{Name: "Darth Vader", SkinColor: "white", Height: "202", Url: "https://swapi.dev/api/people/4/"},
+	}
+
+	testCases := []struct {
+		name     string
+		sortKeys []internalRequest.SortCriteria
+		names    []string
+	}{
+		{
+			name: "text_and_numeric_keys",
+			sortKeys: []internalRequest.SortCriteria{
+				{Field: "skin_color", Order: internalRequest.AscendingOrder},
+				{Field: "height", Order: internalRequest.DescendingOrder},
+			},
+			names: []string{"Luke Skywalker", "Biggs Darklighter", "Owen Lars", "Beru Whitesun lars", "Leia Organa", "Darth Vader"},
+		},
+		{
+			name: "id_tiebreaker",
+			sortKeys: []internalRequest.SortCriteria{
+				{Field: "skin_color", Order: internalRequest.DescendingOrder},
+			},
+			names: []string{"Darth Vader", "Leia Organa", "Owen Lars", "Beru Whitesun lars", "Biggs Darklighter", "Luke Skywalker"},
+		},
+	}
+
+	reversed := slices.Clone(people)
+	slices.Reverse(reversed)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The order doesn't depend on the order of the given resources.
+			for _, results := range [][]Person{slices.Clone(people), slices.Clone(reversed)} {
+				require.NoError(t, SortResults(results, tc.sortKeys...))
+				names := []string{}
+				for _, person := range results {
+					names = append(names, person.Name)
+				}
+				require.Equal(t, tc.names, names)
+			}
+		})
+	}
+}
+
+func TestSortResults_InvalidKey(t *testing.T) {
+	err := SortResults([]Person{}, internalRequest.SortCriteria{Field: "name"}, internalRequest.SortCriteria{Field: "climate"})
+	require.ErrorIs(t, err, ErrInvalidSortField)
+}
+
+func TestCompareIds(t *testing.T) {
+	require.Equal(t, -1, compareIds("https://swapi.dev/api/people/2/", "https://swapi.dev/api/people/10/"))
+	require.Equal(t, 1, compareIds("https://swapi.dev/api/people/10/", "https://swapi.dev/api/people/2/"))
+	require.Equal(t, -1, compareIds("https://swapi.dev/api/people/1/", "https://swapi.py4e.com/api/people/1/"))
+	require.Equal(t, -1, compareIds("https://swapi.dev/api/people/3/", ""))
+	require.Equal(t, 0, compareIds("https://swapi.dev/api/people/1/", "https://swapi.dev/api/people/1/"))
+}
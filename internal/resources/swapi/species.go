@@ -0,0 +1,177 @@
+package swapi
+
+import (
+	"context"
+	"time"
+
+	internalRequest "github.com/pegondo/starwars-service/internal/request"
+)
+
+// speciesEndpoint is the endpoint to request for species in SWAPI.
+const speciesEndpoint = "species"
+
+// Species represents a species in SWAPI.
+// Source: https://swapi.dev/documentation#species
+type Species struct {
+	// Name is the name of the species.
+	Name string `json:"name"`
+	// Classification is the classification of the species, such as "mammal"
+	// or "reptile".
+	Classification string `json:"classification"`
+	// Designation is the designation of the species, such as "sentient".
+	Designation string `json:"designation"`
+	// AverageHeight is the average height of the species in centimeters.
+	AverageHeight string `json:"average_height"`
+	// AverageLifespan is the average lifespan of the species in years.
+	AverageLifespan string `json:"average_lifespan"`
+	// EyeColors is a comma-separated string of common eye colors for the
+	// species, "none" if the species does not typically have eyes.
+	EyeColors string `json:"eye_colors"`
+	// HairColors is a comma-separated string of common hair colors for the
+	// species, "none" if the species does not typically have hair.
+	HairColors string `json:"hair_colors"`
+	// SkinColors is a comma-separated string of common skin colors for the
+	// species, "none" if the species does not typically have skin.
+	SkinColors string `json:"skin_colors"`
+	// Language is the language commonly spoken by the species.
+	Language string `json:"language"`
+	// Homeworld is the URL of the planet this species originates from. It's
+	// nil if the species has no homeworld.
+	Homeworld *string `json:"homeworld"`
+	// People is the list of URLs of the people that are a part of this
+	// species.
+	People []string `json:"people"`
+	// Films is the list of URLs of the films this species has appeared in.
+	Films []string `json:"films"`
+	// Url is the URL to the resource of this species.
+	Url string `json:"url"`
+	// Created is the time when the resource of this species was created.
+	Created time.Time `json:"created"`
+	// Edited is the time when the resource of this species was edited for
+	// the last time.
+	Edited time.Time `json:"edited"`
+	// Expanded contains the resources linked by the species that were
+	// requested to be expanded.
+	Expanded Expansion `json:"expanded,omitempty"`
+	// Score is the relevance of the species for the full-text search query. It's
+	// nil if the species wasn't found with a full-text search.
+	Score *float64 `json:"score,omitempty"`
+}
+
+// GetName returns the species name.
+func (s Species) GetName() string {
+	return s.Name
+}
+
+// GetCreated returns the species' resouce creation time in SWAPI.
+func (s Species) GetCreated() time.Time {
+	return s.Created
+}
+
+// GetUrl returns the URL of the species' resource in SWAPI.
+func (s Species) GetUrl() string {
+	return s.Url
+}
+
+// GetEdited returns the last time the species' resource was edited in SWAPI.
+func (s Species) GetEdited() time.Time {
+	return s.Edited
+}
+
+// fields returns the scalar fields of the species that can be filtered by.
+func (s Species) fields() fields {
+	return fields{
+		"name":             s.Name,
+		"classification":   s.Classification,
+		"designation":      s.Designation,
+		"average_height":   s.AverageHeight,
+		"average_lifespan": s.AverageLifespan,
+		"eye_colors":       s.EyeColors,
+		"hair_colors":      s.HairColors,
+		"skin_colors":      s.SkinColors,
+		"language":         s.Language,
+	}
+}
+
+// links returns the link fields of the species.
+func (s Species) links() links {
+	return links{
+		homeworldLink: optionalLink(s.Homeworld),
+		peopleLink:    s.People,
+		filmsLink:     s.Films,
+	}
+}
+
+// setExpanded sets the expanded resources of the species.
+func (s *Species) setExpanded(expanded Expansion) {
+	s.Expanded = expanded
+}
+
+// setScore sets the relevance of the species for the full-text search query.
+func (s *Species) setScore(score float64) {
+	s.Score = &score
+}
+
+// rewriteUrls replaces the URLs of the species' resource, its links and its
+// expanded resources with the result of rewrite.
+func (s *Species) rewriteUrls(rewrite func(string) string) {
+	s.Url = rewrite(s.Url)
+	s.Homeworld = rewriteOptional(s.Homeworld, rewrite)
+	s.People = rewriteAll(s.People, rewrite)
+	s.Films = rewriteAll(s.Films, rewrite)
+	s.Expanded = s.Expanded.rewriteUrls(rewrite)
+}
+
+// RetrieveSpecies requests the SWAPI for species. The SWAPI doesn't support
+// pagination with variable page sizes, but this function does the maths and
+// requests the endpoint various times if needed to return the data for the
+// given page and page size. If params.Search is not "", the species returned
+// will contain the value of search in their name. If params.Sort isn't empty,
+// the species will be ordered with the defined criteria. The link fields in
+// params.Expand will be expanded.
+func RetrieveSpecies(
+	ctx context.Context,
+	params internalRequest.RequestParams,
+) (
+	speciesResp SwapiResponse[Species],
+	err error,
+) {
+	return retrieveCollection[Species](ctx, speciesEndpoint, params)
+}
+
+// RetrieveSpeciesById requests the SWAPI for the species with the given id. If
+// there is no species with that id, RetrieveSpeciesById returns ErrNotFound.
+// The given link fields will be expanded.
+func RetrieveSpeciesById(ctx context.Context, id int, expandFields []string) (species Species, err error) {
+	return retrieveById[Species](ctx, speciesEndpoint, id, expandFields)
+}
+
+// RetrieveSpeciesPeople requests the SWAPI for the people of the species with
+// the given id. The search, sorting, pagination and expansion in params are
+// applied to the people as in the top-level collections. If there is no species
+// with that id, RetrieveSpeciesPeople returns ErrNotFound.
+func RetrieveSpeciesPeople(
+	ctx context.Context,
+	id int,
+	params internalRequest.RequestParams,
+) (
+	peopleResp SwapiResponse[Person],
+	err error,
+) {
+	return retrieveLinkedCollection[Species, Person](ctx, speciesEndpoint, id, peopleLink, params)
+}
+
+// RetrieveSpeciesFilms requests the SWAPI for the films of the species with the
+// given id. The search, sorting, pagination and expansion in params are applied
+// to the films as in the top-level collections. If there is no species with
+// that id, RetrieveSpeciesFilms returns ErrNotFound.
+func RetrieveSpeciesFilms(
+	ctx context.Context,
+	id int,
+	params internalRequest.RequestParams,
+) (
+	filmsResp SwapiResponse[Film],
+	err error,
+) {
+	return retrieveLinkedCollection[Species, Film](ctx, speciesEndpoint, id, filmsLink, params)
+}
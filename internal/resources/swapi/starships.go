@@ -0,0 +1,192 @@
+package swapi
+
+import (
+	"context"
+	"time"
+
+	internalRequest "github.com/pegondo/starwars-service/internal/request"
+)
+
+// starshipsEndpoint is the endpoint to request for starships in SWAPI.
+const starshipsEndpoint = "starships"
+
+// Starship represents a starship in SWAPI. Starships are resources that have
+// hyperdrive capability.
+// Source: https://swapi.dev/documentation#starships
+type Starship struct {
+	// Name is the name of the starship, such as "Death Star".
+	Name string `json:"name"`
+	// Model is the model or official name of the starship.
+	Model string `json:"model"`
+	// StarshipClass is the class of the starship, such as "Starfighter".
+	StarshipClass string `json:"starship_class"`
+	// Manufacturer is the manufacturer of the starship. Comma separated if
+	// more than one.
+	Manufacturer string `json:"manufacturer"`
+	// CostInCredits is the cost of the starship new, in Galactic Credits.
+	CostInCredits string `json:"cost_in_credits"`
+	// Length is the length of the starship in meters.
+	Length string `json:"length"`
+	// Crew is the number of personnel needed to run or pilot the starship.
+	Crew string `json:"crew"`
+	// Passengers is the number of non-essential people the starship can
+	// transport.
+	Passengers string `json:"passengers"`
+	// MaxAtmospheringSpeed is the maximum speed of the starship in the
+	// atmosphere. "N/A" if the starship is incapable of atmospheric flight.
+	MaxAtmospheringSpeed string `json:"max_atmosphering_speed"`
+	// HyperdriveRating is the class of the starship's hyperdrive.
+	HyperdriveRating string `json:"hyperdrive_rating"`
+	// MGLT is the maximum number of Megalights the starship can travel in a
+	// standard hour.
+	MGLT string `json:"MGLT"`
+	// CargoCapacity is the maximum number of kilograms the starship can
+	// transport.
+	CargoCapacity string `json:"cargo_capacity"`
+	// Consumables is the maximum length of time that the starship can provide
+	// consumables for its entire crew without having to resupply.
+	Consumables string `json:"consumables"`
+	// Pilots is the list of URLs of the people that this starship has been
+	// piloted by.
+	Pilots []string `json:"pilots"`
+	// Films is the list of URLs of the films this starship has appeared in.
+	Films []string `json:"films"`
+	// Url is the URL to the resource of this starship.
+	Url string `json:"url"`
+	// Created is the time when the resource of this starship was created.
+	Created time.Time `json:"created"`
+	// Edited is the time when the resource of this starship was edited for
+	// the last time.
+	Edited time.Time `json:"edited"`
+	// Expanded contains the resources linked by the starship that were
+	// requested to be expanded.
+	Expanded Expansion `json:"expanded,omitempty"`
+	// Score is the relevance of the starship for the full-text search query. It's
+	// nil if the starship wasn't found with a full-text search.
+	Score *float64 `json:"score,omitempty"`
+}
+
+// GetName returns the starship name.
+func (s Starship) GetName() string {
+	return s.Name
+}
+
+// GetCreated returns the starship's resouce creation time in SWAPI.
+func (s Starship) GetCreated() time.Time {
+	return s.Created
+}
+
+// GetUrl returns the URL of the starship's resource in SWAPI.
+func (s Starship) GetUrl() string {
+	return s.Url
+}
+
+// GetEdited returns the last time the starship's resource was edited in SWAPI.
+func (s Starship) GetEdited() time.Time {
+	return s.Edited
+}
+
+// fields returns the scalar fields of the starship that can be filtered by.
+func (s Starship) fields() fields {
+	return fields{
+		"name":                   s.Name,
+		"model":                  s.Model,
+		"starship_class":         s.StarshipClass,
+		"manufacturer":           s.Manufacturer,
+		"cost_in_credits":        s.CostInCredits,
+		"length":                 s.Length,
+		"crew":                   s.Crew,
+		"passengers":             s.Passengers,
+		"max_atmosphering_speed": s.MaxAtmospheringSpeed,
+		"hyperdrive_rating":      s.HyperdriveRating,
+		"mglt":                   s.MGLT,
+		"cargo_capacity":         s.CargoCapacity,
+		"consumables":            s.Consumables,
+	}
+}
+
+// model returns the starship model, which is searched besides its name.
+func (s Starship) model() string {
+	return s.Model
+}
+
+// links returns the link fields of the starship.
+func (s Starship) links() links {
+	return links{
+		pilotsLink: s.Pilots,
+		filmsLink:  s.Films,
+	}
+}
+
+// setExpanded sets the expanded resources of the starship.
+func (s *Starship) setExpanded(expanded Expansion) {
+	s.Expanded = expanded
+}
+
+// setScore sets the relevance of the starship for the full-text search query.
+func (s *Starship) setScore(score float64) {
+	s.Score = &score
+}
+
+// rewriteUrls replaces the URLs of the starship's resource, its links and its
+// expanded resources with the result of rewrite.
+func (s *Starship) rewriteUrls(rewrite func(string) string) {
+	s.Url = rewrite(s.Url)
+	s.Pilots = rewriteAll(s.Pilots, rewrite)
+	s.Films = rewriteAll(s.Films, rewrite)
+	s.Expanded = s.Expanded.rewriteUrls(rewrite)
+}
+
+// RetrieveStarships requests the SWAPI for starships. The SWAPI doesn't support
+// pagination with variable page sizes, but this function does the maths and
+// requests the endpoint various times if needed to return the data for the
+// given page and page size. If params.Search is not "", the starships returned
+// will contain the value of search in their name or model. If params.Sort isn't
+// empty, the starships will be ordered with the defined criteria. The link
+// fields in params.Expand will be expanded.
+func RetrieveStarships(
+	ctx context.Context,
+	params internalRequest.RequestParams,
+) (
+	starshipsResp SwapiResponse[Starship],
+	err error,
+) {
+	return retrieveCollection[Starship](ctx, starshipsEndpoint, params)
+}
+
+// RetrieveStarshipById requests the SWAPI for the starship with the given id.
+// If there is no starship with that id, RetrieveStarshipById returns
+// ErrNotFound. The given link fields will be expanded.
+func RetrieveStarshipById(ctx context.Context, id int, expandFields []string) (starship Starship, err error) {
+	return retrieveById[Starship](ctx, starshipsEndpoint, id, expandFields)
+}
+
+// RetrieveStarshipPilots requests the SWAPI for the pilots of the starship with
+// the given id. The search, sorting, pagination and expansion in params are
+// applied to the pilots as in the top-level collections. If there is no
+// starship with that id, RetrieveStarshipPilots returns ErrNotFound.
+func RetrieveStarshipPilots(
+	ctx context.Context,
+	id int,
+	params internalRequest.RequestParams,
+) (
+	pilotsResp SwapiResponse[Person],
+	err error,
+) {
+	return retrieveLinkedCollection[Starship, Person](ctx, starshipsEndpoint, id, pilotsLink, params)
+}
+
+// RetrieveStarshipFilms requests the SWAPI for the films of the starship with
+// the given id. The search, sorting, pagination and expansion in params are
+// applied to the films as in the top-level collections. If there is no starship
+// with that id, RetrieveStarshipFilms returns ErrNotFound.
+func RetrieveStarshipFilms(
+	ctx context.Context,
+	id int,
+	params internalRequest.RequestParams,
+) (
+	filmsResp SwapiResponse[Film],
+	err error,
+) {
+	return retrieveLinkedCollection[Starship, Film](ctx, starshipsEndpoint, id, filmsLink, params)
+}
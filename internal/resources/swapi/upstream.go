@@ -0,0 +1,200 @@
+package swapi
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pegondo/starwars-service/internal/logger"
+	"github.com/rs/zerolog/log"
+)
+
+// upstream is a SWAPI mirror the resources can be requested to.
+type upstream struct {
+	// baseUrl is the base URL of the mirror, e.g. "https://swapi.dev/api".
+	baseUrl string
+	// breaker stops requesting the mirror while it's failing.
+	breaker *circuitBreaker
+	// unhealthy is whether the last health check of the mirror failed.
+	unhealthy atomic.Bool
+}
+
+// UpstreamStatus represents the status of a SWAPI mirror.
+type UpstreamStatus struct {
+	// Url is the base URL of the mirror.
+	Url string `json:"url"`
+	// Healthy is whether the last health check of the mirror succeeded.
+	Healthy bool `json:"healthy"`
+	// Circuit is the state of the circuit breaker around the mirror.
+	Circuit CircuitState `json:"circuit"`
+}
+
+// parseBaseUrls returns the base URLs in the given comma-separated list,
+// without trailing slashes. If the list is empty, parseBaseUrls returns the
+// default SWAPI base URL.
+func parseBaseUrls(list string) []string {
+	var baseUrls []string
+	for _, baseUrl := range strings.Split(list, ",") {
+		baseUrl = strings.TrimRight(strings.TrimSpace(baseUrl), "/")
+		if baseUrl != "" {
+			baseUrls = append(baseUrls, baseUrl)
+		}
+	}
+	if len(baseUrls) == 0 {
+		return []string{defaultSwapiBaseUrl}
+	}
+	return baseUrls
+}
+
+// newUpstreams returns the mirrors with the given base URLs, each one with its
+// own circuit breaker.
+func newUpstreams(baseUrls []string) []*upstream {
+	upstreams := make([]*upstream, 0, len(baseUrls))
+	for _, baseUrl := range baseUrls {
+		upstreams = append(upstreams, &upstream{
+			baseUrl: baseUrl,
+			breaker: newCircuitBreaker(breakerFailureRatio, breakerMinRequests, breakerWindow, breakerOpenTimeout),
+		})
+	}
+	return upstreams
+}
+
+// orderedUpstreams returns the mirrors in the order they must be tried: the
+// healthy ones first and then the ones whose last health check failed, in
+// case they have recovered since then, both in the configured order.
+func orderedUpstreams() []*upstream {
+	ordered := slices.Clone(upstreams)
+	slices.SortStableFunc(ordered, func(a, b *upstream) int {
+		switch {
+		case a.unhealthy.Load() == b.unhealthy.Load():
+			return 0
+		case b.unhealthy.Load():
+			return -1
+		default:
+			return 1
+		}
+	})
+	return ordered
+}
+
+// fetchFromUpstreams performs a HTTP GET request to the given path of the
+// first SWAPI mirror whose circuit breaker allows it and returns its body and
+// the base URL of the mirror. If the mirror fails, fetchFromUpstreams fails
+// over to the next one and returns the error of the last mirror if all of them
+// fail. If no circuit breaker allows requesting its mirror,
+// fetchFromUpstreams returns ErrUpstreamUnavailable.
+func fetchFromUpstreams(ctx context.Context, path string) (body []byte, baseUrl string, err error) {
+	l := logger.FromContext(ctx)
+	err = ErrUpstreamUnavailable
+	for _, u := range orderedUpstreams() {
+		if !u.breaker.allow() {
+			continue
+		}
+		body, err = fetchOnce(ctx, u.baseUrl+path)
+		healthy := isUpstreamHealthy(err)
+		// A mirror that rate limits the requests isn't failing, so the 429s
+		// don't count as failures for its breaker, but the next mirror is
+		// still tried.
+		u.breaker.record(healthy || errors.Is(err, ErrRateLimited))
+		if healthy {
+			return body, u.baseUrl, err
+		}
+		l.Warn().Msgf("SWAPI mirror %s failed requesting %s :: %v", u.baseUrl, path, err)
+	}
+	return nil, "", err
+}
+
+// checkUpstreamsHealth requests the root of every SWAPI mirror and marks the
+// ones that fail as unhealthy, so they are tried after the healthy ones.
+func checkUpstreamsHealth(ctx context.Context) {
+	for _, u := range upstreams {
+		_, err := fetchOnce(ctx, u.baseUrl+"/")
+		unhealthy := !isUpstreamHealthy(err)
+		if u.unhealthy.Swap(unhealthy) == unhealthy {
+			continue
+		}
+		if unhealthy {
+			log.Warn().Msgf("SWAPI mirror %s is unhealthy :: %v", u.baseUrl, err)
+		} else {
+			log.Info().Msgf("SWAPI mirror %s is healthy again", u.baseUrl)
+		}
+	}
+}
+
+// runHealthChecks checks the health of the SWAPI mirrors right away and then
+// every interval until ctx is done.
+func runHealthChecks(ctx context.Context, interval time.Duration) {
+	checkUpstreamsHealth(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			checkUpstreamsHealth(ctx)
+		}
+	}
+}
+
+// UpstreamStatuses returns the status of every SWAPI mirror, in the configured
+// order.
+func UpstreamStatuses() []UpstreamStatus {
+	statuses := make([]UpstreamStatus, 0, len(upstreams))
+	for _, u := range upstreams {
+		statuses = append(statuses, UpstreamStatus{
+			Url:     u.baseUrl,
+			Healthy: !u.unhealthy.Load(),
+			Circuit: u.breaker.State(),
+		})
+	}
+	return statuses
+}
+
+// servedByKey is the key of the context value that records the SWAPI mirrors
+// that served the requests performed with the context.
+type servedByKey struct{}
+
+// servedBy records the base URLs of the SWAPI mirrors that served the requests
+// performed with a context.
+type servedBy struct {
+	mu       sync.Mutex
+	baseUrls []string
+}
+
+// WithServedBy returns a copy of ctx that records the SWAPI mirrors that serve
+// the requests performed with it, which are returned by ServedBy.
+func WithServedBy(ctx context.Context) context.Context {
+	return context.WithValue(ctx, servedByKey{}, &servedBy{})
+}
+
+// ServedBy returns the base URLs of the SWAPI mirrors that served the requests
+// performed with ctx, in the order they were first used. The responses served
+// from the cache aren't recorded.
+func ServedBy(ctx context.Context) []string {
+	s, ok := ctx.Value(servedByKey{}).(*servedBy)
+	if !ok {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.baseUrls)
+}
+
+// recordServedBy records that the SWAPI mirror with the given base URL served
+// a request performed with ctx.
+func recordServedBy(ctx context.Context, baseUrl string) {
+	s, ok := ctx.Value(servedByKey{}).(*servedBy)
+	if !ok || baseUrl == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !slices.Contains(s.baseUrls, baseUrl) {
+		s.baseUrls = append(s.baseUrls, baseUrl)
+	}
+}
@@ -0,0 +1,197 @@
+package swapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// setUpstreams sets the SWAPI mirrors for a test, the first one being the
+// preferred one, and restores the original ones when the test finishes.
+func setUpstreams(t *testing.T, u ...*upstream) {
+	originalBaseUrl, originalUpstreams := swapiBaseUrl, upstreams
+	swapiBaseUrl, upstreams = u[0].baseUrl, u
+	t.Cleanup(func() { swapiBaseUrl, upstreams = originalBaseUrl, originalUpstreams })
+}
+
+// useServer sets the test server with the given URL as the only SWAPI mirror
+// for a test. Its circuit breaker never opens, so the failures the test
+// provokes don't affect the rest of the requests.
+func useServer(t *testing.T, baseUrl string) {
+	setUpstreams(t, newTestUpstream(baseUrl))
+}
+
+// newTestUpstream returns a SWAPI mirror with the given base URL and a circuit
+// breaker that never opens.
+func newTestUpstream(baseUrl string) *upstream {
+	return &upstream{
+		baseUrl: baseUrl,
+		breaker: newCircuitBreaker(0, 0, time.Minute, time.Minute),
+	}
+}
+
+// newStatusServer returns a test server that responds to every request with
+// the given status and counts the requests.
+func newStatusServer(t *testing.T, status int, numRequests *atomic.Int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(`{"name":"Luke Skywalker"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestParseBaseUrls(t *testing.T) {
+	tcs := []struct {
+		name     string
+		list     string
+		expected []string
+	}{
+		{
+			name:     "single base URL",
+			list:     "https://swapi.dev/api",
+			expected: []string{"https://swapi.dev/api"},
+		},
+		{
+			name:     "multiple base URLs",
+			list:     "https://swapi.dev/api/, https://swapi.py4e.com/api ,",
+			expected: []string{"https://swapi.dev/api", "https://swapi.py4e.com/api"},
+		},
+		{
+			name:     "empty list",
+			list:     " , ",
+			expected: []string{defaultSwapiBaseUrl},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, parseBaseUrls(tc.list))
+		})
+	}
+}
+
+func TestFetch_Failover(t *testing.T) {
+	setRetryConfig(t, 1, time.Millisecond, 10*time.Millisecond)
+	var numPrimaryRequests, numSecondaryRequests atomic.Int32
+	primary := newStatusServer(t, http.StatusServiceUnavailable, &numPrimaryRequests)
+	secondary := newStatusServer(t, http.StatusOK, &numSecondaryRequests)
+	setUpstreams(t, newTestUpstream(primary.URL), newTestUpstream(secondary.URL))
+
+	ctx := WithServedBy(context.Background())
+	body, err := fetch(ctx, primary.URL+"/people/1/")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"Luke Skywalker"}`, string(body))
+	require.Equal(t, int32(1), numPrimaryRequests.Load())
+	require.Equal(t, int32(1), numSecondaryRequests.Load())
+	require.Equal(t, []string{secondary.URL}, ServedBy(ctx))
+}
+
+func TestFetch_FailoverBreakerOpen(t *testing.T) {
+	setRetryConfig(t, 1, time.Millisecond, 10*time.Millisecond)
+	var numPrimaryRequests, numSecondaryRequests atomic.Int32
+	primary := newStatusServer(t, http.StatusServiceUnavailable, &numPrimaryRequests)
+	secondary := newStatusServer(t, http.StatusOK, &numSecondaryRequests)
+	setUpstreams(t,
+		&upstream{
+			baseUrl: primary.URL,
+			breaker: newCircuitBreaker(0.5, 1, time.Minute, time.Minute),
+		},
+		newTestUpstream(secondary.URL),
+	)
+
+	_, err := fetch(context.Background(), primary.URL+"/people/1/")
+	require.NoError(t, err)
+	// The breaker of the primary mirror is open, so it isn't requested again.
+	_, err = fetch(context.Background(), primary.URL+"/people/2/")
+	require.NoError(t, err)
+	require.Equal(t, int32(1), numPrimaryRequests.Load())
+	require.Equal(t, int32(2), numSecondaryRequests.Load())
+	require.Equal(t, CircuitClosed, BreakerState())
+}
+
+func TestFetch_AllUpstreamsFail(t *testing.T) {
+	setRetryConfig(t, 1, time.Millisecond, 10*time.Millisecond)
+	var numRequests atomic.Int32
+	primary := newStatusServer(t, http.StatusBadGateway, &numRequests)
+	secondary := newStatusServer(t, http.StatusServiceUnavailable, &numRequests)
+	setUpstreams(t, newTestUpstream(primary.URL), newTestUpstream(secondary.URL))
+
+	ctx := WithServedBy(context.Background())
+	_, err := fetch(ctx, primary.URL+"/people/1/")
+	require.ErrorIs(t, err, ErrUpstreamError)
+	require.Equal(t, int32(2), numRequests.Load())
+	require.Empty(t, ServedBy(ctx))
+}
+
+func TestFetch_NotSwapiUrl(t *testing.T) {
+	useServer(t, "http://localhost:1")
+
+	_, err := fetch(context.Background(), "http://example.com/people/1/")
+	require.ErrorIs(t, err, ErrUpstreamError)
+}
+
+func TestCheckUpstreamsHealth(t *testing.T) {
+	var numPrimaryRequests, numSecondaryRequests atomic.Int32
+	primary := newStatusServer(t, http.StatusInternalServerError, &numPrimaryRequests)
+	secondary := newStatusServer(t, http.StatusOK, &numSecondaryRequests)
+	setUpstreams(t, newTestUpstream(primary.URL), newTestUpstream(secondary.URL))
+
+	checkUpstreamsHealth(context.Background())
+	require.Equal(t, []UpstreamStatus{
+		{Url: primary.URL, Healthy: false, Circuit: CircuitClosed},
+		{Url: secondary.URL, Healthy: true, Circuit: CircuitClosed},
+	}, UpstreamStatuses())
+
+	// The unhealthy mirror is tried after the healthy one.
+	ctx := WithServedBy(context.Background())
+	_, err := fetch(ctx, primary.URL+"/people/1/")
+	require.NoError(t, err)
+	require.Equal(t, []string{secondary.URL}, ServedBy(ctx))
+	require.Equal(t, int32(1), numPrimaryRequests.Load())
+}
+
+func TestRunHealthChecks_ChecksRightAway(t *testing.T) {
+	var numRequests atomic.Int32
+	server := newStatusServer(t, http.StatusInternalServerError, &numRequests)
+	useServer(t, server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		runHealthChecks(ctx, time.Hour)
+	}()
+	// The first check mustn't wait for the interval.
+	require.Eventually(t, func() bool {
+		return !UpstreamStatuses()[0].Healthy
+	}, time.Second, time.Millisecond)
+	cancel()
+	<-done
+}
+
+func TestFetch_RateLimitedDoesNotOpenBreaker(t *testing.T) {
+	setRetryConfig(t, 1, time.Millisecond, 10*time.Millisecond)
+	var numRequests atomic.Int32
+	server := newStatusServer(t, http.StatusTooManyRequests, &numRequests)
+	setUpstreams(t, &upstream{
+		baseUrl: server.URL,
+		breaker: newCircuitBreaker(0.5, 1, time.Minute, time.Minute),
+	})
+
+	for i := range 3 {
+		_, err := fetch(context.Background(), fmt.Sprintf("%s/people/%d/", server.URL, i+1))
+		require.ErrorIs(t, err, ErrRateLimited)
+	}
+	// The mirror is rate limiting the requests, not failing, so its breaker
+	// stays closed and every request reaches it.
+	require.Equal(t, int32(3), numRequests.Load())
+	require.Equal(t, CircuitClosed, BreakerState())
+}
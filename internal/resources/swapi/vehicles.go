@@ -0,0 +1,185 @@
+package swapi
+
+import (
+	"context"
+	"time"
+
+	internalRequest "github.com/pegondo/starwars-service/internal/request"
+)
+
+// vehiclesEndpoint is the endpoint to request for vehicles in SWAPI.
+const vehiclesEndpoint = "vehicles"
+
+// Vehicle represents a vehicle in SWAPI. Vehicles are resources that do not
+// have hyperdrive capability.
+// Source: https://swapi.dev/documentation#vehicles
+type Vehicle struct {
+	// Name is the name of the vehicle, such as "Sand Crawler".
+	Name string `json:"name"`
+	// Model is the model or official name of the vehicle.
+	Model string `json:"model"`
+	// VehicleClass is the class of the vehicle, such as "Wheeled".
+	VehicleClass string `json:"vehicle_class"`
+	// Manufacturer is the manufacturer of the vehicle. Comma separated if
+	// more than one.
+	Manufacturer string `json:"manufacturer"`
+	// Length is the length of the vehicle in meters.
+	Length string `json:"length"`
+	// CostInCredits is the cost of the vehicle new, in Galactic Credits.
+	CostInCredits string `json:"cost_in_credits"`
+	// Crew is the number of personnel needed to run or pilot the vehicle.
+	Crew string `json:"crew"`
+	// Passengers is the number of non-essential people the vehicle can
+	// transport.
+	Passengers string `json:"passengers"`
+	// MaxAtmospheringSpeed is the maximum speed of the vehicle in the
+	// atmosphere.
+	MaxAtmospheringSpeed string `json:"max_atmosphering_speed"`
+	// CargoCapacity is the maximum number of kilograms the vehicle can
+	// transport.
+	CargoCapacity string `json:"cargo_capacity"`
+	// Consumables is the maximum length of time that the vehicle can provide
+	// consumables for its entire crew without having to resupply.
+	Consumables string `json:"consumables"`
+	// Pilots is the list of URLs of the people that this vehicle has been
+	// piloted by.
+	Pilots []string `json:"pilots"`
+	// Films is the list of URLs of the films this vehicle has appeared in.
+	Films []string `json:"films"`
+	// Url is the URL to the resource of this vehicle.
+	Url string `json:"url"`
+	// Created is the time when the resource of this vehicle was created.
+	Created time.Time `json:"created"`
+	// Edited is the time when the resource of this vehicle was edited for
+	// the last time.
+	Edited time.Time `json:"edited"`
+	// Expanded contains the resources linked by the vehicle that were
+	// requested to be expanded.
+	Expanded Expansion `json:"expanded,omitempty"`
+	// Score is the relevance of the vehicle for the full-text search query. It's
+	// nil if the vehicle wasn't found with a full-text search.
+	Score *float64 `json:"score,omitempty"`
+}
+
+// GetName returns the vehicle name.
+func (v Vehicle) GetName() string {
+	return v.Name
+}
+
+// GetCreated returns the vehicle's resouce creation time in SWAPI.
+func (v Vehicle) GetCreated() time.Time {
+	return v.Created
+}
+
+// GetUrl returns the URL of the vehicle's resource in SWAPI.
+func (v Vehicle) GetUrl() string {
+	return v.Url
+}
+
+// GetEdited returns the last time the vehicle's resource was edited in SWAPI.
+func (v Vehicle) GetEdited() time.Time {
+	return v.Edited
+}
+
+// fields returns the scalar fields of the vehicle that can be filtered by.
+func (v Vehicle) fields() fields {
+	return fields{
+		"name":                   v.Name,
+		"model":                  v.Model,
+		"vehicle_class":          v.VehicleClass,
+		"manufacturer":           v.Manufacturer,
+		"length":                 v.Length,
+		"cost_in_credits":        v.CostInCredits,
+		"crew":                   v.Crew,
+		"passengers":             v.Passengers,
+		"max_atmosphering_speed": v.MaxAtmospheringSpeed,
+		"cargo_capacity":         v.CargoCapacity,
+		"consumables":            v.Consumables,
+	}
+}
+
+// model returns the vehicle model, which is searched besides its name.
+func (v Vehicle) model() string {
+	return v.Model
+}
+
+// links returns the link fields of the vehicle.
+func (v Vehicle) links() links {
+	return links{
+		pilotsLink: v.Pilots,
+		filmsLink:  v.Films,
+	}
+}
+
+// setExpanded sets the expanded resources of the vehicle.
+func (v *Vehicle) setExpanded(expanded Expansion) {
+	v.Expanded = expanded
+}
+
+// setScore sets the relevance of the vehicle for the full-text search query.
+func (v *Vehicle) setScore(score float64) {
+	v.Score = &score
+}
+
+// rewriteUrls replaces the URLs of the vehicle's resource, its links and its
+// expanded resources with the result of rewrite.
+func (v *Vehicle) rewriteUrls(rewrite func(string) string) {
+	v.Url = rewrite(v.Url)
+	v.Pilots = rewriteAll(v.Pilots, rewrite)
+	v.Films = rewriteAll(v.Films, rewrite)
+	v.Expanded = v.Expanded.rewriteUrls(rewrite)
+}
+
+// RetrieveVehicles requests the SWAPI for vehicles. The SWAPI doesn't support
+// pagination with variable page sizes, but this function does the maths and
+// requests the endpoint various times if needed to return the data for the
+// given page and page size. If params.Search is not "", the vehicles returned
+// will contain the value of search in their name or model. If params.Sort isn't
+// empty, the vehicles will be ordered with the defined criteria. The link
+// fields in params.Expand will be expanded.
+func RetrieveVehicles(
+	ctx context.Context,
+	params internalRequest.RequestParams,
+) (
+	vehiclesResp SwapiResponse[Vehicle],
+	err error,
+) {
+	return retrieveCollection[Vehicle](ctx, vehiclesEndpoint, params)
+}
+
+// RetrieveVehicleById requests the SWAPI for the vehicle with the given id. If
+// there is no vehicle with that id, RetrieveVehicleById returns ErrNotFound.
+// The given link fields will be expanded.
+func RetrieveVehicleById(ctx context.Context, id int, expandFields []string) (vehicle Vehicle, err error) {
+	return retrieveById[Vehicle](ctx, vehiclesEndpoint, id, expandFields)
+}
+
+// RetrieveVehiclePilots requests the SWAPI for the pilots of the vehicle with
+// the given id. The search, sorting, pagination and expansion in params are
+// applied to the pilots as in the top-level collections. If there is no vehicle
+// with that id, RetrieveVehiclePilots returns ErrNotFound.
+func RetrieveVehiclePilots(
+	ctx context.Context,
+	id int,
+	params internalRequest.RequestParams,
+) (
+	pilotsResp SwapiResponse[Person],
+	err error,
+) {
+	return retrieveLinkedCollection[Vehicle, Person](ctx, vehiclesEndpoint, id, pilotsLink, params)
+}
+
+// RetrieveVehicleFilms requests the SWAPI for the films of the vehicle with the
+// given id. The search, sorting, pagination and expansion in params are applied
+// to the films as in the top-level collections. If there is no vehicle with
+// that id, RetrieveVehicleFilms returns ErrNotFound.
+func RetrieveVehicleFilms(
+	ctx context.Context,
+	id int,
+	params internalRequest.RequestParams,
+) (
+	filmsResp SwapiResponse[Film],
+	err error,
+) {
+	return retrieveLinkedCollection[Vehicle, Film](ctx, vehiclesEndpoint, id, filmsLink, params)
+}
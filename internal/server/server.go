@@ -5,10 +5,12 @@ import (
 	"github.com/pegondo/starwars-service/internal/handler"
 	"github.com/pegondo/starwars-service/internal/logger"
 	"github.com/pegondo/starwars-service/internal/request"
+	"github.com/pegondo/starwars-service/internal/resources/swapi"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 )
 
 // router is the router instance.
@@ -16,13 +18,47 @@ var router *gin.Engine
 
 // Init initializes the local router instance.
 func Init() {
+	backends, err := swapi.Init()
+	if err != nil {
+		log.Fatal().Msgf("error while initializing the SWAPI backends :: %v", err)
+	}
+	h := handler.New(backends)
+
 	router = gin.Default()
 
 	router.Use(cors.Default(), errors.RecoveryMiddleware(), request.RequestIdMiddleware(), logger.Middleware())
 
-	api := router.Group("/api")
-	api.GET(handler.PeopleEndpoint, handler.RetrievePeople)
-	api.GET(handler.PlanetEndpoint, handler.RetrievePlanets)
+	api := router.Group(handler.ApiBasePath)
+	api.GET(handler.HealthEndpoint, handler.Health)
+	api.GET(handler.PeopleEndpoint, h.RetrievePeople)
+	api.GET(handler.PlanetEndpoint, h.RetrievePlanets)
+	api.GET(handler.FilmsEndpoint, h.RetrieveFilms)
+	api.GET(handler.SpeciesEndpoint, h.RetrieveSpecies)
+	api.GET(handler.VehiclesEndpoint, h.RetrieveVehicles)
+	api.GET(handler.StarshipsEndpoint, h.RetrieveStarships)
+	api.GET(handler.PeopleByIdEndpoint, h.RetrievePersonById)
+	api.GET(handler.PlanetByIdEndpoint, h.RetrievePlanetById)
+	api.GET(handler.FilmsByIdEndpoint, h.RetrieveFilmById)
+	api.GET(handler.SpeciesByIdEndpoint, h.RetrieveSpeciesById)
+	api.GET(handler.VehiclesByIdEndpoint, h.RetrieveVehicleById)
+	api.GET(handler.StarshipsByIdEndpoint, h.RetrieveStarshipById)
+	api.GET(handler.PersonFilmsEndpoint, h.RetrievePersonFilms)
+	api.GET(handler.PersonSpeciesEndpoint, h.RetrievePersonSpecies)
+	api.GET(handler.PersonVehiclesEndpoint, h.RetrievePersonVehicles)
+	api.GET(handler.PersonStarshipsEndpoint, h.RetrievePersonStarships)
+	api.GET(handler.PlanetResidentsEndpoint, h.RetrievePlanetResidents)
+	api.GET(handler.PlanetFilmsEndpoint, h.RetrievePlanetFilms)
+	api.GET(handler.FilmCharactersEndpoint, h.RetrieveFilmCharacters)
+	api.GET(handler.FilmPlanetsEndpoint, h.RetrieveFilmPlanets)
+	api.GET(handler.FilmStarshipsEndpoint, h.RetrieveFilmStarships)
+	api.GET(handler.FilmVehiclesEndpoint, h.RetrieveFilmVehicles)
+	api.GET(handler.FilmSpeciesEndpoint, h.RetrieveFilmSpecies)
+	api.GET(handler.SpeciesPeopleEndpoint, h.RetrieveSpeciesPeople)
+	api.GET(handler.SpeciesFilmsEndpoint, h.RetrieveSpeciesFilms)
+	api.GET(handler.VehiclePilotsEndpoint, h.RetrieveVehiclePilots)
+	api.GET(handler.VehicleFilmsEndpoint, h.RetrieveVehicleFilms)
+	api.GET(handler.StarshipPilotsEndpoint, h.RetrieveStarshipPilots)
+	api.GET(handler.StarshipFilmsEndpoint, h.RetrieveStarshipFilms)
 
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 }
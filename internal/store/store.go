@@ -0,0 +1,150 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidKey is the error returned when a bucket or a key can't be used as
+// a file name.
+var ErrInvalidKey = errors.New("invalid key")
+
+// Store is an embedded key/value store persisted on disk and safe for
+// concurrent use. The keys are grouped in buckets, and each value is stored in
+// its own file, <dir>/<bucket>/<key>, which is replaced atomically, so a crash
+// never leaves a partially written value behind.
+type Store struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+// Open opens the store in the given directory, creating it if it doesn't
+// exist.
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error while creating the store directory :: %v", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Get returns the value stored with the given key in the given bucket. If
+// there is no value, ok will be false.
+func (s *Store) Get(bucket, key string) (value []byte, ok bool, err error) {
+	path, err := s.path(bucket, key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, err = os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("error while reading the key %s/%s :: %v", bucket, key, err)
+	}
+	return value, true, nil
+}
+
+// Put stores the value with the given key in the given bucket, replacing any
+// previous value.
+func (s *Store) Put(bucket, key string, value []byte) error {
+	path, err := s.path(bucket, key)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error while creating the bucket %s :: %v", bucket, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+key+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("error while writing the key %s/%s :: %v", bucket, key, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(value); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		return fmt.Errorf("error while writing the key %s/%s :: %v", bucket, key, err)
+	}
+	return nil
+}
+
+// Delete removes the value stored with the given key in the given bucket, if
+// any.
+func (s *Store) Delete(bucket, key string) error {
+	path, err := s.path(bucket, key)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error while deleting the key %s/%s :: %v", bucket, key, err)
+	}
+	return nil
+}
+
+// All returns every value stored in the given bucket by key. If the bucket
+// doesn't exist, All returns an empty map.
+func (s *Store) All(bucket string) (map[string][]byte, error) {
+	if !validName(bucket) {
+		return nil, fmt.Errorf("%w %q", ErrInvalidKey, bucket)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	values := map[string][]byte{}
+	entries, err := os.ReadDir(filepath.Join(s.dir, bucket))
+	if errors.Is(err, os.ErrNotExist) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error while reading the bucket %s :: %v", bucket, err)
+	}
+	for _, entry := range entries {
+		// Skip the temporary files of the writes that didn't finish.
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		value, err := os.ReadFile(filepath.Join(s.dir, bucket, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error while reading the key %s/%s :: %v", bucket, entry.Name(), err)
+		}
+		values[entry.Name()] = value
+	}
+	return values, nil
+}
+
+// path returns the path of the file that holds the value of the given key in
+// the given bucket.
+func (s *Store) path(bucket, key string) (string, error) {
+	if !validName(bucket) {
+		return "", fmt.Errorf("%w %q", ErrInvalidKey, bucket)
+	}
+	if !validName(key) {
+		return "", fmt.Errorf("%w %q", ErrInvalidKey, key)
+	}
+	return filepath.Join(s.dir, bucket, key), nil
+}
+
+// validName returns whether the given bucket or key can be used as a file name
+// inside the store directory.
+func validName(name string) bool {
+	return name != "" && !strings.HasPrefix(name, ".") && !strings.ContainsAny(name, `/\`)
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutGet(t *testing.T) {
+	s, err := Open(t.TempDir())
+	require.NoError(t, err)
+
+	_, ok, err := s.Get("<bucket>", "<key>")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, s.Put("<bucket>", "<key>", []byte("1")))
+	value, ok, err := s.Get("<bucket>", "<key>")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("1"), value)
+
+	require.NoError(t, s.Put("<bucket>", "<key>", []byte("2")))
+	value, ok, err = s.Get("<bucket>", "<key>")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("2"), value)
+}
+
+func TestPersistence(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir)
+	require.NoError(t, err)
+	require.NoError(t, s.Put("<bucket>", "<key>", []byte("1")))
+
+	reopened, err := Open(dir)
+	require.NoError(t, err)
+	value, ok, err := reopened.Get("<bucket>", "<key>")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("1"), value)
+}
+
+func TestDelete(t *testing.T) {
+	s, err := Open(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, s.Put("<bucket>", "<key>", []byte("1")))
+
+	require.NoError(t, s.Delete("<bucket>", "<key>"))
+	_, ok, err := s.Get("<bucket>", "<key>")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Deleting a missing key isn't an error.
+	require.NoError(t, s.Delete("<bucket>", "<key>"))
+}
+
+func TestAll(t *testing.T) {
+	s, err := Open(t.TempDir())
+	require.NoError(t, err)
+
+	values, err := s.All("<bucket>")
+	require.NoError(t, err)
+	require.Empty(t, values)
+
+	require.NoError(t, s.Put("<bucket>", "1", []byte("a")))
+	require.NoError(t, s.Put("<bucket>", "2", []byte("b")))
+	require.NoError(t, s.Put("<other-bucket>", "3", []byte("c")))
+
+	values, err = s.All("<bucket>")
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"1": []byte("a"), "2": []byte("b")}, values)
+}
+
+func TestInvalidKey(t *testing.T) {
+	s, err := Open(t.TempDir())
+	require.NoError(t, err)
+
+	tcs := []struct {
+		name   string
+		bucket string
+		key    string
+	}{
+		{name: "empty key", bucket: "<bucket>", key: ""},
+		{name: "parent directory", bucket: "<bucket>", key: ".."},
+		{name: "path separator", bucket: "<bucket>", key: "a/b"},
+		{name: "empty bucket", bucket: "", key: "<key>"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := s.Put(tc.bucket, tc.key, []byte("1"))
+			require.ErrorIs(t, err, ErrInvalidKey)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lpernett/godotenv"
+	"github.com/rs/zerolog/log"
+)
+
+// loadEnvOnce loads the .env file, if any, only once.
+var loadEnvOnce sync.Once
+
+// lookupEnv returns the value of the environment variable with the given key,
+// loading the .env file first.
+func lookupEnv(key string) (string, bool) {
+	loadEnvOnce.Do(func() {
+		godotenv.Load()
+	})
+	return os.LookupEnv(key)
+}
+
+// EnvString returns the value of the environment variable with the given key.
+// If it isn't defined, EnvString returns the default value provided.
+func EnvString(key, defaultValue string) string {
+	if value, exists := lookupEnv(key); exists {
+		return value
+	}
+	return defaultValue
+}
+
+// EnvInt returns the value of the environment variable with the given key as
+// an integer. If it isn't defined or it isn't an integer, EnvInt returns the
+// default value provided.
+func EnvInt(key string, defaultValue int) int {
+	valueStr, exists := lookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		log.Warn().Msgf("invalid integer in the %s environment variable, using %d :: %v", key, defaultValue, err)
+		return defaultValue
+	}
+	return value
+}
+
+// EnvDuration returns the value of the environment variable with the given key
+// as a duration, such as "30s" or "1h". If it isn't defined or it isn't a
+// duration, EnvDuration returns the default value provided.
+func EnvDuration(key string, defaultValue time.Duration) time.Duration {
+	valueStr, exists := lookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	value, err := time.ParseDuration(valueStr)
+	if err != nil {
+		log.Warn().Msgf("invalid duration in the %s environment variable, using %s :: %v", key, defaultValue, err)
+		return defaultValue
+	}
+	return value
+}
+
+// EnvFloat returns the value of the environment variable with the given key as
+// a floating-point number. If it isn't defined or it isn't a number, EnvFloat
+// returns the default value provided.
+func EnvFloat(key string, defaultValue float64) float64 {
+	valueStr, exists := lookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		log.Warn().Msgf("invalid number in the %s environment variable, using %g :: %v", key, defaultValue, err)
+		return defaultValue
+	}
+	return value
+}
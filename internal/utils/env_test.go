@@ -0,0 +1,119 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pegondo/starwars-service/internal/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+// envKey is the environment variable used in the tests.
+const envKey = "STARWARS_SERVICE_TEST_ENV"
+
+func TestEnvString(t *testing.T) {
+	require.Equal(t, "<default>", utils.EnvString(envKey, "<default>"))
+
+	t.Setenv(envKey, "<value>")
+	require.Equal(t, "<value>", utils.EnvString(envKey, "<default>"))
+}
+
+func TestEnvInt(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    *string
+		expected int
+	}{
+		{
+			name:     "undefined",
+			value:    nil,
+			expected: 10,
+		},
+		{
+			name:     "valid",
+			value:    func() *string { v := "3"; return &v }(),
+			expected: 3,
+		},
+		{
+			name:     "invalid",
+			value:    func() *string { v := "<invalid>"; return &v }(),
+			expected: 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.value != nil {
+				t.Setenv(envKey, *tc.value)
+			}
+			require.Equal(t, tc.expected, utils.EnvInt(envKey, 10))
+		})
+	}
+}
+
+func TestEnvDuration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    *string
+		expected time.Duration
+	}{
+		{
+			name:     "undefined",
+			value:    nil,
+			expected: time.Minute,
+		},
+		{
+			name:     "valid",
+			value:    func() *string { v := "30s"; return &v }(),
+			expected: 30 * time.Second,
+		},
+		{
+			name:     "invalid",
+			value:    func() *string { v := "<invalid>"; return &v }(),
+			expected: time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.value != nil {
+				t.Setenv(envKey, *tc.value)
+			}
+			require.Equal(t, tc.expected, utils.EnvDuration(envKey, time.Minute))
+		})
+	}
+}
+
+func TestEnvFloat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    *string
+		expected float64
+	}{
+		{
+			name:     "undefined",
+			value:    nil,
+			expected: 0.5,
+		},
+		{
+			name:     "valid",
+			value:    func() *string { v := "0.25"; return &v }(),
+			expected: 0.25,
+		},
+		{
+			name:     "invalid",
+			value:    func() *string { v := "<invalid>"; return &v }(),
+			expected: 0.5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.value != nil {
+				t.Setenv(envKey, *tc.value)
+			}
+			require.Equal(t, tc.expected, utils.EnvFloat(envKey, 0.5))
+		})
+	}
+}
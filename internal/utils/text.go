@@ -0,0 +1,16 @@
+package utils
+
+import (
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// FoldCase returns the given text case folded and Unicode normalized, so the
+// texts that only differ in their case or in how their characters are encoded
+// are equal, e.g. "PADMÉ" and "padmé" both fold to "padmé", and
+// "Straße" to "strasse". Unlike strings.ToLower, it also matches the letters
+// whose lowercase isn't their case folding, such as "ß" and "ẞ".
+func FoldCase(text string) string {
+	// A caser isn't safe for concurrent use, so a new one is used each time.
+	return norm.NFKC.String(cases.Fold().String(norm.NFD.String(text)))
+}
@@ -0,0 +1,64 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/pegondo/starwars-service/internal/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFoldCase(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "ascii",
+			text:     "Luke SKYWALKER",
+			expected: "luke skywalker",
+		},
+		{
+			name:     "composed_accent",
+			text:     "PADMÉ",
+			expected: "padmé",
+		},
+		{
+			name:     "decomposed_accent",
+			text:     "Padme\u0301",
+			expected: "padmé",
+		},
+		{
+			name:     "sharp_s",
+			text:     "Straße",
+			expected: "strasse",
+		},
+		{
+			name:     "capital_sharp_s",
+			text:     "STRAẞE",
+			expected: "strasse",
+		},
+		{
+			name:     "final_sigma",
+			text:     "ΟΔΥΣΣΕΥΣ",
+			expected: "οδυσσευσ",
+		},
+		{
+			name:     "fullwidth",
+			text:     "ＹＯＤＡ",
+			expected: "yoda",
+		},
+		{
+			name:     "url_characters",
+			text:     "Luke&Page=9#Films",
+			expected: "luke&page=9#films",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, utils.FoldCase(tc.text))
+		})
+	}
+}
@@ -268,3 +268,84 @@ func TestRetrievePeople_InvalidSortField(t *testing.T) {
 	require.Equal(t, expectedErr, err)
 	require.Nil(t, resp.Response.Data)
 }
+
+func TestRetrievePersonById(t *testing.T) {
+	resp, err := c.RetrievePersonById("1", client.NewRequestOpts("", "", "", "", ""))
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotEmpty(t, resp.Resource.Name)
+}
+
+func TestRetrievePersonById_NotFound(t *testing.T) {
+	expectedErr := &errors.ResponseError{
+		ErrorCode:    errors.ResourceNotFoundErrorCode,
+		ErrorMessage: errors.ResourceNotFoundErrorMsg,
+	}
+	resp, err := c.RetrievePersonById(strconv.Itoa(math.MaxInt32), client.NewRequestOpts("", "", "", "", ""))
+
+	require.Equal(t, expectedErr, err)
+	require.Empty(t, resp.Resource.Name)
+}
+
+func TestRetrievePersonById_InvalidId(t *testing.T) {
+	expectedErr := &errors.ResponseError{
+		ErrorCode:    errors.InvalidIdErrorCode,
+		ErrorMessage: errors.InvalidIdErrorMsg,
+	}
+	resp, err := c.RetrievePersonById("<invalid-id>", client.NewRequestOpts("", "", "", "", ""))
+
+	require.Equal(t, expectedErr, err)
+	require.Empty(t, resp.Resource.Name)
+}
+
+func TestRetrievePeople_ExpandHomeworldAndFilms(t *testing.T) {
+	opts := client.NewRequestOpts("1", pageSizeStr, "", "", "").WithExpand("homeworld,films")
+	resp, err := c.RetrievePeople(opts)
+
+	require.NoError(t, err)
+	require.Contains(t, []int{http.StatusOK, http.StatusPartialContent}, resp.StatusCode)
+	require.NotEmpty(t, resp.Response.Data)
+
+	// Verify that the people contain their homeworld and films expanded.
+	for _, person := range resp.Response.Data {
+		require.Contains(t, person.Expanded, "homeworld")
+		require.Contains(t, person.Expanded, "films")
+		require.Len(t, person.Expanded["films"], len(person.Films))
+	}
+}
+
+func TestRetrievePersonById_ExpandHomeworld(t *testing.T) {
+	opts := client.NewRequestOpts("", "", "", "", "").WithExpand("homeworld")
+	resp, err := c.RetrievePersonById("1", opts)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotNil(t, resp.Resource.Expanded["homeworld"])
+}
+
+func TestRetrievePeople_InvalidExpand(t *testing.T) {
+	expectedErr := &errors.ResponseError{
+		ErrorCode:    errors.InvalidExpandErrorCode,
+		ErrorMessage: errors.InvalidExpandErrorMsg,
+	}
+	opts := client.NewRequestOpts("1", pageSizeStr, "", "", "").WithExpand("residents")
+	resp, err := c.RetrievePeople(opts)
+
+	require.Equal(t, expectedErr, err)
+	require.Nil(t, resp.Response.Data)
+}
+
+func TestRetrievePersonFilms(t *testing.T) {
+	person, err := c.RetrievePersonById("1", client.NewRequestOpts("", "", "", "", ""))
+	require.NoError(t, err)
+
+	resp, err := c.RetrievePersonFilms("1", client.NewRequestOpts("1", pageSizeStr, "", "", ""))
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, len(person.Resource.Films), resp.Response.Count)
+	for _, film := range resp.Response.Data {
+		require.Contains(t, person.Resource.Films, film.Url)
+	}
+}
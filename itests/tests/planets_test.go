@@ -268,3 +268,70 @@ func TestRetrievePlanets_InvalidSortField(t *testing.T) {
 	require.Equal(t, expectedErr, err)
 	require.Nil(t, resp.Response.Data)
 }
+
+func TestRetrievePlanetById(t *testing.T) {
+	resp, err := c.RetrievePlanetById("1", client.NewRequestOpts("", "", "", "", ""))
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotEmpty(t, resp.Resource.Name)
+}
+
+func TestRetrievePlanetById_NotFound(t *testing.T) {
+	expectedErr := &errors.ResponseError{
+		ErrorCode:    errors.ResourceNotFoundErrorCode,
+		ErrorMessage: errors.ResourceNotFoundErrorMsg,
+	}
+	resp, err := c.RetrievePlanetById(strconv.Itoa(math.MaxInt32), client.NewRequestOpts("", "", "", "", ""))
+
+	require.Equal(t, expectedErr, err)
+	require.Empty(t, resp.Resource.Name)
+}
+
+func TestRetrievePlanetById_InvalidId(t *testing.T) {
+	expectedErr := &errors.ResponseError{
+		ErrorCode:    errors.InvalidIdErrorCode,
+		ErrorMessage: errors.InvalidIdErrorMsg,
+	}
+	resp, err := c.RetrievePlanetById("<invalid-id>", client.NewRequestOpts("", "", "", "", ""))
+
+	require.Equal(t, expectedErr, err)
+	require.Empty(t, resp.Resource.Name)
+}
+
+func TestRetrievePlanetResidents_Page1_SortByNameAsc(t *testing.T) {
+	sortCriteria := request.SortCriteria{
+		Field: request.NameSortField,
+		Order: request.AscendingOrder,
+	}
+	resp, err := c.RetrievePlanetResidents("1", client.NewRequestOpts("1", pageSizeStr, "", string(sortCriteria.Field), string(sortCriteria.Order)))
+
+	require.NoError(t, err)
+	require.Contains(t, []int{http.StatusOK, http.StatusPartialContent}, resp.StatusCode)
+	require.NotNil(t, resp.Response.Data)
+
+	// Verify that the residents are sorted by name in ascending order.
+	sortedResidents := make([]swapi.Person, len(resp.Response.Data))
+	copy(sortedResidents, resp.Response.Data)
+	swapi.SortResults(sortedResidents, sortCriteria)
+	require.Equal(t, sortedResidents, resp.Response.Data)
+}
+
+func TestRetrievePlanetFilms_Page1_PageSize1(t *testing.T) {
+	resp, err := c.RetrievePlanetFilms("1", client.NewRequestOpts("1", "1", "", "", ""))
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	require.Equal(t, 1, len(resp.Response.Data))
+}
+
+func TestRetrievePlanetResidents_NotFound(t *testing.T) {
+	expectedErr := &errors.ResponseError{
+		ErrorCode:    errors.ResourceNotFoundErrorCode,
+		ErrorMessage: errors.ResourceNotFoundErrorMsg,
+	}
+	resp, err := c.RetrievePlanetResidents(strconv.Itoa(math.MaxInt32), client.NewRequestOpts("1", pageSizeStr, "", "", ""))
+
+	require.Equal(t, expectedErr, err)
+	require.Nil(t, resp.Response.Data)
+}
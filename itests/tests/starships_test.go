@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pegondo/starwars-service/ex/client"
+	"github.com/pegondo/starwars-service/internal/errors"
+	"github.com/pegondo/starwars-service/internal/request"
+	"github.com/pegondo/starwars-service/internal/resources/swapi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrieveStarships_Page1_PageSize1(t *testing.T) {
+	resp, err := c.RetrieveStarships(client.NewRequestOpts("1", "1", "", "", ""))
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	require.NotNil(t, resp.Response.Data)
+	require.Equal(t, 1, len(resp.Response.Data))
+}
+
+func TestRetrieveStarships_Page1_Search(t *testing.T) {
+	search := "a"
+	resp, err := c.RetrieveStarships(client.NewRequestOpts("1", pageSizeStr, search, "", ""))
+
+	require.NoError(t, err)
+	require.Contains(t, []int{http.StatusOK, http.StatusPartialContent}, resp.StatusCode)
+	require.NotNil(t, resp.Response.Data)
+	require.NotEmpty(t, resp.Response.Data)
+
+	// Verify that all the starships contain the searched segment.
+	for _, starship := range resp.Response.Data {
+		require.Contains(t, strings.ToLower(starship.Name), strings.ToLower(search))
+	}
+}
+
+func TestRetrieveStarships_Page1_SortByNameAsc(t *testing.T) {
+	sortCriteria := request.SortCriteria{
+		Field: request.NameSortField,
+		Order: request.AscendingOrder,
+	}
+	resp, err := c.RetrieveStarships(client.NewRequestOpts("1", pageSizeStr, "", string(sortCriteria.Field), string(sortCriteria.Order)))
+
+	require.NoError(t, err)
+	require.Contains(t, []int{http.StatusOK, http.StatusPartialContent}, resp.StatusCode)
+	require.NotNil(t, resp.Response.Data)
+	require.NotEmpty(t, resp.Response.Data)
+
+	// Verify that the starships are sorted by name in ascending order.
+	sortedStarships := make([]swapi.Starship, len(resp.Response.Data))
+	copy(sortedStarships, resp.Response.Data)
+	swapi.SortResults(sortedStarships, sortCriteria)
+	require.Equal(t, sortedStarships, resp.Response.Data)
+}
+
+func TestRetrieveStarships_InvalidPageParam(t *testing.T) {
+	expectedErr := &errors.ResponseError{
+		ErrorCode:    errors.InvalidPageErrorCode,
+		ErrorMessage: errors.InvalidPageErrorMsg,
+	}
+	resp, err := c.RetrieveStarships(client.NewRequestOpts("<invalid-page>", pageSizeStr, "", "", ""))
+
+	require.Equal(t, expectedErr, err)
+	require.Nil(t, resp.Response.Data)
+}
@@ -1,8 +1,44 @@
 package main
 
-import "github.com/pegondo/starwars-service/internal/server"
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/pegondo/starwars-service/internal/resources/swapi"
+	"github.com/pegondo/starwars-service/internal/server"
+
+	"github.com/rs/zerolog/log"
+)
+
+// snapshotCommand is the command that writes a snapshot of SWAPI instead of
+// running the service.
+const snapshotCommand = "snapshot"
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == snapshotCommand {
+		takeSnapshot(os.Args[2:])
+		return
+	}
+
 	server.Init()
 	server.Run()
 }
+
+// takeSnapshot retrieves every resource from SWAPI and writes them to the
+// snapshot file given in the arguments, which can be served later with
+// SWAPI_DATASET.
+func takeSnapshot(args []string) {
+	flags := flag.NewFlagSet(snapshotCommand, flag.ExitOnError)
+	output := flags.String("o", "swapi-snapshot.json", "the path of the snapshot file to write")
+	flags.Parse(args)
+
+	snapshot, err := swapi.TakeSnapshot(context.Background())
+	if err != nil {
+		log.Fatal().Msgf("error while taking the SWAPI snapshot :: %v", err)
+	}
+	if err = swapi.WriteSnapshot(*output, snapshot); err != nil {
+		log.Fatal().Msgf("error while writing the SWAPI snapshot :: %v", err)
+	}
+	log.Info().Msgf("SWAPI snapshot written to %s", *output)
+}
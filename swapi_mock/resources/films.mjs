@@ -0,0 +1,58 @@
+import {
+  getPseudoRandomString,
+  getPseudoRandomElement,
+  getPseudoRandomDate,
+} from "./pseudoRandomGenerator.mjs";
+import {
+  NUM_ELEMENTS,
+  getResourceUrl,
+  getPseudoRandomUrls,
+} from "./urls.mjs";
+
+const VALID_DIRECTORS = ["George Lucas", "Irvin Kershner", "Richard Marquand"];
+
+const VALID_PRODUCERS = ["Gary Kurtz", "Rick McCallum", "Howard G. Kazanjian"];
+
+const VALID_RELEASE_DATES = [
+  "1977-05-25",
+  "1980-05-17",
+  "1983-05-25",
+  "1999-05-19",
+  "2002-05-16",
+  "2005-05-19",
+];
+
+const FILMS = [...new Array(NUM_ELEMENTS.films)].map((_, index) => {
+  const title = getPseudoRandomString("Title", index);
+  const episode_id = index + 1;
+  const opening_crawl = getPseudoRandomString("Crawl", index);
+  const director = getPseudoRandomElement(VALID_DIRECTORS, index);
+  const producer = getPseudoRandomElement(VALID_PRODUCERS, index);
+  const release_date = getPseudoRandomElement(VALID_RELEASE_DATES, index);
+  const characters = getPseudoRandomUrls("people", index, 5);
+  const planets = getPseudoRandomUrls("planets", index, 3);
+  const starships = getPseudoRandomUrls("starships", index, 2);
+  const vehicles = getPseudoRandomUrls("vehicles", index, 2);
+  const species = getPseudoRandomUrls("species", index, 2);
+  const url = getResourceUrl("films", index);
+  const created = getPseudoRandomDate(index);
+  const edited = getPseudoRandomDate(index);
+  return {
+    title,
+    episode_id,
+    opening_crawl,
+    director,
+    producer,
+    release_date,
+    characters,
+    planets,
+    starships,
+    vehicles,
+    species,
+    url,
+    created,
+    edited,
+  };
+});
+
+export default FILMS;
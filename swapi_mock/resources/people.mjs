@@ -3,8 +3,11 @@ import {
   getPseudoRandomElement,
   getPseudoRandomDate,
 } from "./pseudoRandomGenerator.mjs";
-
-const NUM_ELEMENTS = 55;
+import {
+  NUM_ELEMENTS,
+  getResourceUrl,
+  getPseudoRandomUrls,
+} from "./urls.mjs";
 
 const VALID_COLORS = ["brown", "blue", "green", "black"];
 
@@ -24,7 +27,7 @@ const VALID_HEIGHTS = [
 
 const VALID_MASSES = ["60", "65", "70", "75", "80", "85", "90", "95", "100"];
 
-const PEOPLE = [...new Array(NUM_ELEMENTS)].map((_, index) => {
+const PEOPLE = [...new Array(NUM_ELEMENTS.people)].map((_, index) => {
   const name = getPseudoRandomString("Name", index);
   const birth_year = getPseudoRandomDate(index);
   const eye_color = getPseudoRandomElement(VALID_COLORS, index);
@@ -33,7 +36,12 @@ const PEOPLE = [...new Array(NUM_ELEMENTS)].map((_, index) => {
   const height = getPseudoRandomElement(VALID_HEIGHTS, index);
   const mass = getPseudoRandomElement(VALID_MASSES, index);
   const skin_color = getPseudoRandomElement(VALID_COLORS, index);
-  const url = getPseudoRandomString("people", index);
+  const homeworld = getResourceUrl("planets", index % NUM_ELEMENTS.planets);
+  const films = getPseudoRandomUrls("films", index, 3);
+  const species = getPseudoRandomUrls("species", index, 1);
+  const vehicles = getPseudoRandomUrls("vehicles", index, index % 3);
+  const starships = getPseudoRandomUrls("starships", index, index % 2);
+  const url = getResourceUrl("people", index);
   const created = getPseudoRandomDate(index);
   const edited = getPseudoRandomDate(index);
   return {
@@ -45,6 +53,11 @@ const PEOPLE = [...new Array(NUM_ELEMENTS)].map((_, index) => {
     height,
     mass,
     skin_color,
+    homeworld,
+    films,
+    species,
+    vehicles,
+    starships,
     url,
     created,
     edited,
@@ -3,8 +3,11 @@ import {
   getPseudoRandomElement,
   getPseudoRandomDate,
 } from "./pseudoRandomGenerator.mjs";
-
-const NUM_ELEMENTS = 55;
+import {
+  NUM_ELEMENTS,
+  getResourceUrl,
+  getPseudoRandomUrls,
+} from "./urls.mjs";
 
 const VALID_DIAMETERS = ["5000", "10000", "15000", "20000", "25000", "30000"];
 
@@ -22,7 +25,7 @@ const VALID_TERRAINS = ["mountain", "beach", "lake", "ocean"];
 
 const VALID_SURFACE_WATER = ["0%", "25%", "50%", "75%", "100%"];
 
-const PLANETS = [...new Array(NUM_ELEMENTS)].map((_, index) => {
+const PLANETS = [...new Array(NUM_ELEMENTS.planets)].map((_, index) => {
   const name = getPseudoRandomString("Name", index);
   const diameter = getPseudoRandomElement(VALID_DIAMETERS, index);
   const rotation_period = getPseudoRandomElement(VALID_ROTATION_PERIODS, index);
@@ -32,7 +35,9 @@ const PLANETS = [...new Array(NUM_ELEMENTS)].map((_, index) => {
   const climate = getPseudoRandomElement(VALID_CLIMATES, index);
   const terrain = getPseudoRandomElement(VALID_TERRAINS, index);
   const surface_water = getPseudoRandomElement(VALID_SURFACE_WATER, index);
-  const url = getPseudoRandomString("planets", index);
+  const residents = getPseudoRandomUrls("people", index, index % 4);
+  const films = getPseudoRandomUrls("films", index, 2);
+  const url = getResourceUrl("planets", index);
   const created = getPseudoRandomDate(index);
   const edited = getPseudoRandomDate(index);
   return {
@@ -45,6 +50,8 @@ const PLANETS = [...new Array(NUM_ELEMENTS)].map((_, index) => {
     climate,
     terrain,
     surface_water,
+    residents,
+    films,
     url,
     created,
     edited,
@@ -0,0 +1,62 @@
+import {
+  getPseudoRandomString,
+  getPseudoRandomElement,
+  getPseudoRandomDate,
+} from "./pseudoRandomGenerator.mjs";
+import {
+  NUM_ELEMENTS,
+  getResourceUrl,
+  getPseudoRandomUrls,
+} from "./urls.mjs";
+
+const VALID_CLASSIFICATIONS = ["mammal", "reptile", "amphibian", "artificial"];
+
+const VALID_DESIGNATIONS = ["sentient", "reptilian"];
+
+const VALID_AVERAGE_HEIGHTS = ["100", "150", "180", "210", "n/a"];
+
+const VALID_AVERAGE_LIFESPANS = ["80", "120", "400", "indefinite", "unknown"];
+
+const VALID_COLORS = ["brown, blue", "green", "black", "none"];
+
+const VALID_LANGUAGES = ["Galactic Basic", "Shyriiwook", "Huttese", "n/a"];
+
+const SPECIES = [...new Array(NUM_ELEMENTS.species)].map((_, index) => {
+  const name = getPseudoRandomString("Name", index);
+  const classification = getPseudoRandomElement(VALID_CLASSIFICATIONS, index);
+  const designation = getPseudoRandomElement(VALID_DESIGNATIONS, index);
+  const average_height = getPseudoRandomElement(VALID_AVERAGE_HEIGHTS, index);
+  const average_lifespan = getPseudoRandomElement(
+    VALID_AVERAGE_LIFESPANS,
+    index
+  );
+  const eye_colors = getPseudoRandomElement(VALID_COLORS, index);
+  const hair_colors = getPseudoRandomElement(VALID_COLORS, index);
+  const skin_colors = getPseudoRandomElement(VALID_COLORS, index);
+  const language = getPseudoRandomElement(VALID_LANGUAGES, index);
+  const homeworld = getResourceUrl("planets", index % NUM_ELEMENTS.planets);
+  const people = getPseudoRandomUrls("people", index, 2);
+  const films = getPseudoRandomUrls("films", index, 2);
+  const url = getResourceUrl("species", index);
+  const created = getPseudoRandomDate(index);
+  const edited = getPseudoRandomDate(index);
+  return {
+    name,
+    classification,
+    designation,
+    average_height,
+    average_lifespan,
+    eye_colors,
+    hair_colors,
+    skin_colors,
+    language,
+    homeworld,
+    people,
+    films,
+    url,
+    created,
+    edited,
+  };
+});
+
+export default SPECIES;
@@ -0,0 +1,78 @@
+import {
+  getPseudoRandomString,
+  getPseudoRandomElement,
+  getPseudoRandomDate,
+} from "./pseudoRandomGenerator.mjs";
+import {
+  NUM_ELEMENTS,
+  getResourceUrl,
+  getPseudoRandomUrls,
+} from "./urls.mjs";
+
+const VALID_CLASSES = ["Starfighter", "Deep Space Mobile Battlestation", "Corvette"];
+
+const VALID_MANUFACTURERS = ["Kuat Drive Yards", "Corellian Engineering Corporation"];
+
+const VALID_COSTS = ["3500000", "150000", "1,000,000,000,000", "unknown"];
+
+const VALID_LENGTHS = ["12.5", "34.37", "150", "120000"];
+
+const VALID_CREWS = ["1", "4", "30-165", "342,953"];
+
+const VALID_PASSENGERS = ["0", "6", "600", "843,342"];
+
+const VALID_SPEEDS = ["1050", "950", "n/a"];
+
+const VALID_HYPERDRIVE_RATINGS = ["0.5", "1.0", "2.0", "4.0"];
+
+const VALID_MGLTS = ["10", "60", "75", "100"];
+
+const VALID_CARGO_CAPACITIES = ["110", "3000000", "1000000000000"];
+
+const VALID_CONSUMABLES = ["1 week", "2 months", "3 years"];
+
+const STARSHIPS = [...new Array(NUM_ELEMENTS.starships)].map((_, index) => {
+  const name = getPseudoRandomString("Name", index);
+  const model = getPseudoRandomString("Model", index);
+  const starship_class = getPseudoRandomElement(VALID_CLASSES, index);
+  const manufacturer = getPseudoRandomElement(VALID_MANUFACTURERS, index);
+  const cost_in_credits = getPseudoRandomElement(VALID_COSTS, index);
+  const length = getPseudoRandomElement(VALID_LENGTHS, index);
+  const crew = getPseudoRandomElement(VALID_CREWS, index);
+  const passengers = getPseudoRandomElement(VALID_PASSENGERS, index);
+  const max_atmosphering_speed = getPseudoRandomElement(VALID_SPEEDS, index);
+  const hyperdrive_rating = getPseudoRandomElement(
+    VALID_HYPERDRIVE_RATINGS,
+    index
+  );
+  const MGLT = getPseudoRandomElement(VALID_MGLTS, index);
+  const cargo_capacity = getPseudoRandomElement(VALID_CARGO_CAPACITIES, index);
+  const consumables = getPseudoRandomElement(VALID_CONSUMABLES, index);
+  const pilots = getPseudoRandomUrls("people", index, index % 3);
+  const films = getPseudoRandomUrls("films", index, 2);
+  const url = getResourceUrl("starships", index);
+  const created = getPseudoRandomDate(index);
+  const edited = getPseudoRandomDate(index);
+  return {
+    name,
+    model,
+    starship_class,
+    manufacturer,
+    cost_in_credits,
+    length,
+    crew,
+    passengers,
+    max_atmosphering_speed,
+    hyperdrive_rating,
+    MGLT,
+    cargo_capacity,
+    consumables,
+    pilots,
+    films,
+    url,
+    created,
+    edited,
+  };
+});
+
+export default STARSHIPS;
@@ -0,0 +1,20 @@
+export const BASE_URL = "http://localhost:3000";
+
+// NUM_ELEMENTS is the number of elements in each collection.
+export const NUM_ELEMENTS = {
+  people: 55,
+  planets: 55,
+  films: 25,
+  species: 35,
+  vehicles: 40,
+  starships: 36,
+};
+
+// SWAPI ids start at 1.
+export const getResourceUrl = (endpoint, index) =>
+  `${BASE_URL}/${endpoint}/${index + 1}/`;
+
+export const getPseudoRandomUrls = (endpoint, index, count) =>
+  [...new Array(count)].map((_, i) =>
+    getResourceUrl(endpoint, (index * 7 + i * 3) % NUM_ELEMENTS[endpoint])
+  );
@@ -0,0 +1,67 @@
+import {
+  getPseudoRandomString,
+  getPseudoRandomElement,
+  getPseudoRandomDate,
+} from "./pseudoRandomGenerator.mjs";
+import {
+  NUM_ELEMENTS,
+  getResourceUrl,
+  getPseudoRandomUrls,
+} from "./urls.mjs";
+
+const VALID_CLASSES = ["wheeled", "repulsorcraft", "walker", "airspeeder"];
+
+const VALID_MANUFACTURERS = ["Corellia Mining Corporation", "Incom Corporation"];
+
+const VALID_LENGTHS = ["3.4", "10.4", "20", "36.8"];
+
+const VALID_COSTS = ["8000", "14750", "150000", "unknown"];
+
+const VALID_CREWS = ["1", "2", "5", "46"];
+
+const VALID_PASSENGERS = ["0", "1", "30", "40"];
+
+const VALID_SPEEDS = ["30", "90", "650", "1000"];
+
+const VALID_CARGO_CAPACITIES = ["10", "50", "50000", "none"];
+
+const VALID_CONSUMABLES = ["none", "1 day", "2 months"];
+
+const VEHICLES = [...new Array(NUM_ELEMENTS.vehicles)].map((_, index) => {
+  const name = getPseudoRandomString("Name", index);
+  const model = getPseudoRandomString("Model", index);
+  const vehicle_class = getPseudoRandomElement(VALID_CLASSES, index);
+  const manufacturer = getPseudoRandomElement(VALID_MANUFACTURERS, index);
+  const length = getPseudoRandomElement(VALID_LENGTHS, index);
+  const cost_in_credits = getPseudoRandomElement(VALID_COSTS, index);
+  const crew = getPseudoRandomElement(VALID_CREWS, index);
+  const passengers = getPseudoRandomElement(VALID_PASSENGERS, index);
+  const max_atmosphering_speed = getPseudoRandomElement(VALID_SPEEDS, index);
+  const cargo_capacity = getPseudoRandomElement(VALID_CARGO_CAPACITIES, index);
+  const consumables = getPseudoRandomElement(VALID_CONSUMABLES, index);
+  const pilots = getPseudoRandomUrls("people", index, index % 2);
+  const films = getPseudoRandomUrls("films", index, 1);
+  const url = getResourceUrl("vehicles", index);
+  const created = getPseudoRandomDate(index);
+  const edited = getPseudoRandomDate(index);
+  return {
+    name,
+    model,
+    vehicle_class,
+    manufacturer,
+    length,
+    cost_in_credits,
+    crew,
+    passengers,
+    max_atmosphering_speed,
+    cargo_capacity,
+    consumables,
+    pilots,
+    films,
+    url,
+    created,
+    edited,
+  };
+});
+
+export default VEHICLES;
@@ -1,8 +1,11 @@
 import express from "express";
 import PEOPLE from "./resources/people.mjs";
 import PLANETS from "./resources/planets.mjs";
-
-const BASE_URL = "http://localhost:3000";
+import FILMS from "./resources/films.mjs";
+import SPECIES from "./resources/species.mjs";
+import VEHICLES from "./resources/vehicles.mjs";
+import STARSHIPS from "./resources/starships.mjs";
+import { BASE_URL } from "./resources/urls.mjs";
 
 // SWAPI had a fixed page size.
 const PAGE_SIZE = 10;
@@ -22,10 +25,11 @@ const getQueryParams = (req) => ({
   search: getSearchQueryParam(req),
 });
 
+// Films are the only resource without a name, SWAPI searches them by title.
 const filterResources = (resources, search) =>
   search
-    ? resources.filter(({ name }) =>
-        name.toLowerCase().includes(search.toLowerCase())
+    ? resources.filter(({ name, title }) =>
+        (name ?? title).toLowerCase().includes(search.toLowerCase())
       )
     : resources;
 
@@ -75,20 +79,34 @@ const applyQueryParams = (endpoint, resources, { page, search }) => {
   };
 };
 
-app.get("/people", (req, res) => {
-  const params = getQueryParams(req);
+const COLLECTIONS = {
+  people: PEOPLE,
+  planets: PLANETS,
+  films: FILMS,
+  species: SPECIES,
+  vehicles: VEHICLES,
+  starships: STARSHIPS,
+};
 
-  const response = applyQueryParams("people", PEOPLE, params);
+Object.entries(COLLECTIONS).forEach(([endpoint, resources]) => {
+  app.get(`/${endpoint}`, (req, res) => {
+    const params = getQueryParams(req);
 
-  res.json(response);
-});
+    const response = applyQueryParams(endpoint, resources, params);
 
-app.get("/planets", (req, res) => {
-  const params = getQueryParams(req);
+    res.json(response);
+  });
 
-  const response = applyQueryParams("planets", PLANETS, params);
+  // SWAPI ids start at 1.
+  app.get(`/${endpoint}/:id`, (req, res) => {
+    const resource = resources[parseInt(req.params.id) - 1];
+    if (resource === undefined) {
+      res.status(404).json({ detail: "Not found" });
+      return;
+    }
 
-  res.json(response);
+    res.json(resource);
+  });
 });
 
 app.listen(port, () => {